@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/devwithpeet/content-checker/pkg"
+)
+
+type Command string
+
+const (
+	PrintCommand             Command = "print"
+	ErrorsCommand            Command = "errors"
+	StatsCommand             Command = "stats"
+	VersionCommand           Command = "version"
+	CheckPageOrderCommand    Command = "check-page-order"
+	CheckChapterOrderCommand Command = "check-chapter-order"
+	CheckLinksCommand        Command = "check-links"
+	RulesCommand             Command = "rules"
+	ConfigCommand            Command = "config"
+	ConfigValidateCommand    Command = "config validate"
+	FixCommand               Command = "fix"
+	RenumberCommand          Command = "renumber"
+	NewCommand               Command = "new"
+	NewPageCommand           Command = "new page"
+	NewPracticeCommand       Command = "new practice"
+	NewChapterCommand        Command = "new chapter"
+	OrphansCommand           Command = "orphans"
+	AssetsCommand            Command = "assets"
+)
+
+// exit codes, content errors are kept apart from usage errors so that CI can tell a broken page from a broken job
+const (
+	exitContentErrors = 1
+	exitUsageError    = 2
+)
+
+type options struct {
+	command       Command
+	root          string
+	courseWanted  string
+	statesAllowed map[pkg.State]struct{}
+	verbose       bool
+	printIndex    bool
+	printNonIndex bool
+	maxErrors     int
+	tagsWanted    []string
+	checkExternal bool
+	refresh       bool
+	format        pkg.Format
+	dryRun        bool
+	// args are the positional arguments of commands parsing them on their own, e.g. new page
+	args []string
+}
+
+type commandSpec struct {
+	command     Command
+	args        string
+	description string
+	flags       []string
+	// -1 means that the command checks its arguments on its own
+	maxArgs int
+}
+
+var crawlFlags = []string{"verbose", "max-errors", "tags"}
+
+var commandSpecs = []commandSpec{
+	{PrintCommand, "[root] [course]", "Print the courses with the state and the issues of each page.", append([]string{"state", "with-index", "without-non-index"}, crawlFlags...), 2},
+	{ErrorsCommand, "[root] [course]", "Report the issues of each page.", append([]string{"format"}, crawlFlags...), 2},
+	{StatsCommand, "[root] [course]", "Print statistics about the state of the courses.", crawlFlags, 2},
+	{CheckPageOrderCommand, "[root] [course]", "Report missing, duplicate and weird page weights.", crawlFlags, 2},
+	{CheckChapterOrderCommand, "[root] [course]", "Report missing and duplicate chapter weights.", crawlFlags, 2},
+	{CheckLinksCommand, "[root]", "Check internal, external and file links.", []string{"check-external", "refresh", "format", "verbose", "max-errors"}, 1},
+	{OrphansCommand, "[root]", "Report pages which are not linked from any other page.", []string{"format", "verbose"}, 1},
+	{AssetsCommand, "[root]", "Report unreferenced files in static/ and page bundles, and file links with the wrong case.", []string{"format", "verbose"}, 1},
+	{FixCommand, "[root] [course]", "Fix slugs, tag case, states and file names.", append([]string{"dry-run"}, crawlFlags...), 2},
+	{RenumberCommand, "[root] [course]", "Renumber page and chapter weights, keeping the current order.", append([]string{"dry-run"}, crawlFlags...), 2},
+	{NewPageCommand, "<course>/<chapter> <title> [root]", "Create a new lesson at the end of a chapter.", nil, -1},
+	{NewPracticeCommand, "<course>/<chapter> <title> [root]", "Create a new practice page at the end of a chapter.", nil, -1},
+	{NewChapterCommand, "<course> <title> [root]", "Create a new chapter at the end of a course.", nil, -1},
+	{ConfigValidateCommand, "[root]", "Validate the configuration file.", nil, 1},
+	{RulesCommand, "", "List the rules with their codes and severities.", nil, 0},
+	{VersionCommand, "", "Print the version.", nil, 0},
+}
+
+func getCommandSpec(command Command) (commandSpec, bool) {
+	for _, spec := range commandSpecs {
+		if spec.command == command {
+			return spec, true
+		}
+	}
+
+	return commandSpec{}, false
+}
+
+// stateValue is a repeatable flag collecting the states to print, values can also be comma separated
+type stateValue map[pkg.State]struct{}
+
+func (s stateValue) String() string {
+	states := make([]string, 0, len(s))
+	for state := range s {
+		states = append(states, string(state))
+	}
+
+	return strings.Join(states, ",")
+}
+
+func (s stateValue) Set(raw string) error {
+	for _, part := range strings.Split(raw, ",") {
+		switch state := pkg.State(strings.TrimSpace(part)); state {
+		case pkg.Complete, pkg.Incomplete, pkg.Stub:
+			s[state] = struct{}{}
+		default:
+			return fmt.Errorf("unknown state: %s", part)
+		}
+	}
+
+	return nil
+}
+
+func newFlagSet(spec commandSpec, opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(string(spec.command), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	for _, name := range spec.flags {
+		switch name {
+		case "verbose":
+			fs.BoolVar(&opts.verbose, name, false, "print the files and courses found")
+		case "max-errors":
+			fs.IntVar(&opts.maxErrors, name, defaulMaxErrors, "stop after this many files with issues, -1 means no limit")
+		case "tags":
+			fs.Func(name, "only process pages with one of these comma separated `tags`", func(raw string) error {
+				for _, tag := range strings.Split(raw, ",") {
+					opts.tagsWanted = append(opts.tagsWanted, strings.TrimSpace(tag))
+				}
+
+				return nil
+			})
+		case "state":
+			fs.Var(stateValue(opts.statesAllowed), name, "only print pages in this `state` (complete, incomplete, stub), can be repeated")
+		case "with-index":
+			fs.BoolVar(&opts.printIndex, name, false, "also print the _index.md pages")
+		case "without-non-index":
+			fs.BoolFunc(name, "do not print the pages other than _index.md", func(string) error {
+				opts.printNonIndex = false
+
+				return nil
+			})
+		case "format":
+			fs.Func(name, "output `format`: text, json or sarif", func(raw string) error {
+				format, err := pkg.ParseFormat(raw)
+				opts.format = format
+
+				return err
+			})
+		case "check-external":
+			fs.BoolVar(&opts.checkExternal, name, false, "also check external links")
+		case "refresh":
+			fs.BoolVar(&opts.refresh, name, false, "check external links again, even if they are in the link cache")
+		case "dry-run":
+			fs.BoolVar(&opts.dryRun, name, false, "only show the changes without applying them")
+		}
+	}
+
+	return fs
+}
+
+func getArgs(args []string) (options, error) {
+	opts := options{
+		command:       PrintCommand,
+		root:          ".",
+		statesAllowed: map[pkg.State]struct{}{},
+		printNonIndex: true,
+		maxErrors:     defaulMaxErrors,
+		tagsWanted:    []string{},
+		format:        pkg.TextFormat,
+	}
+
+	start := 1
+	if len(args) > 1 {
+		opts.command = Command(args[1])
+		start = 2
+	}
+
+	switch opts.command {
+	case "help", "-h", "-help", "--help":
+		opts.command = ""
+
+		return opts, flag.ErrHelp
+	}
+
+	// some commands have subcommands, e.g. config validate
+	if (opts.command == ConfigCommand || opts.command == NewCommand) && len(args) > 2 && !strings.HasPrefix(args[2], "-") {
+		opts.command = Command(string(opts.command) + " " + args[2])
+		start = 3
+	}
+
+	spec, ok := getCommandSpec(opts.command)
+	if !ok {
+		return opts, fmt.Errorf("unknown command: %s", opts.command)
+	}
+
+	fs := newFlagSet(spec, &opts)
+
+	// flags and positional arguments can be mixed, so parsing continues after each positional argument
+	rest := args[start:]
+	for {
+		if err := fs.Parse(rest); err != nil {
+			return opts, err
+		}
+
+		if fs.NArg() == 0 {
+			break
+		}
+
+		opts.args = append(opts.args, fs.Arg(0))
+		rest = fs.Args()[1:]
+	}
+
+	if spec.maxArgs >= 0 && len(opts.args) > spec.maxArgs {
+		return opts, fmt.Errorf("unexpected argument: %s", opts.args[spec.maxArgs])
+	}
+
+	if spec.maxArgs >= 0 {
+		if len(opts.args) > 0 {
+			opts.root = opts.args[0]
+		}
+		if len(opts.args) > 1 {
+			opts.courseWanted = opts.args[1]
+		}
+	}
+
+	if len(opts.statesAllowed) == 0 {
+		opts.statesAllowed = map[pkg.State]struct{}{
+			pkg.Complete:   {},
+			pkg.Incomplete: {},
+			pkg.Stub:       {},
+		}
+	}
+
+	return opts, nil
+}
+
+func printUsage(w io.Writer, command Command) {
+	spec, ok := getCommandSpec(command)
+	if !ok {
+		fmt.Fprintln(w, "Usage: content-checker <command> [flags] [arguments]")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Commands:")
+		for _, spec := range commandSpecs {
+			fmt.Fprintf(w, "  %-20s %s\n", spec.command, spec.description)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Use content-checker <command> --help for the flags of a command.")
+
+		return
+	}
+
+	fmt.Fprintln(w, strings.TrimSpace(fmt.Sprintf("Usage: content-checker %s [flags] %s", spec.command, spec.args)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, spec.description)
+
+	if len(spec.flags) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+
+	fs := newFlagSet(spec, &options{statesAllowed: map[pkg.State]struct{}{}})
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+func usageError(command Command, err error) {
+	fmt.Fprintln(os.Stderr, err)
+	fmt.Fprintln(os.Stderr)
+	printUsage(os.Stderr, command)
+	os.Exit(exitUsageError)
+}
+
+func checkUsage(opts options, err error) {
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage(os.Stdout, opts.command)
+		os.Exit(0)
+	}
+
+	if err != nil {
+		usageError(opts.command, err)
+	}
+}
@@ -3,9 +3,11 @@ module github.com/devwithpeet/content-checker
 go 1.23.4
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/gosimple/slug v1.14.0
 	github.com/peteraba/sortedmap v0.0.0-20241208160612-912ca9484a44
 	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,5 +15,4 @@ require (
 	github.com/gosimple/unidecode v1.0.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/exp v0.0.0-20241204233417-43b7b7cde48d // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
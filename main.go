@@ -1,12 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
+	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -15,134 +18,64 @@ import (
 	sm "github.com/peteraba/sortedmap"
 )
 
-type Command string
-
 const Version = "0.7.3"
 
-const (
-	PrintCommand             Command = "print"
-	ErrorsCommand            Command = "errors"
-	StatsCommand             Command = "stats"
-	VersionCommand           Command = "version"
-	CheckPageOrderCommand    Command = "check-page-order"
-	CheckChapterOrderCommand Command = "check-chapter-order"
-	CheckLinksCommand        Command = "check-links"
-)
-
-func getArgs(args []string) (Command, string, map[pkg.State]struct{}, bool, bool, bool, string, int, []string, bool) {
-	var err error
-
-	action := PrintCommand
-	if len(args) > 1 {
-		action = Command(args[1])
-	}
+func main() {
+	opts, err := getArgs(os.Args)
+	checkUsage(opts, err)
 
-	statesAllowed := map[pkg.State]struct{}{}
+	switch opts.command {
+	case VersionCommand:
+		fmt.Println("Version:", Version)
 
-	checkExternal := false
-	verbose := false
-	printIndex := false
-	printNonIndex := true
-	rootFound := false
-	root := "."
-	courseWanted := ""
-	maxErrors := defaulMaxErrors
-	tagsWanted := []string{}
+		return
 
-	if len(args) > 2 {
-		for i := 2; i < len(args); i++ {
-			arg := args[i]
-
-			switch arg {
-			case "--without-non-index", "-without-non-index":
-				printNonIndex = false
-			case "--with-index", "-with-index":
-				printIndex = true
-			case "--check-external", "-check-external":
-				checkExternal = true
-			case "--verbose", "-verbose":
-				verbose = true
-			case "--tags", "-tags":
-				if len(args) <= i+1 {
-					panic("missing value for --tags")
-				}
+	case RulesCommand:
+		Rules()
 
-				for _, tag := range strings.Split(args[i+1], ",") {
-					tagsWanted = append(tagsWanted, strings.TrimSpace(tag))
-				}
+		return
 
-				i++
-			case "--max-errors", "-max-errors":
-				if len(args) <= i+1 {
-					panic("missing value for --max-errors")
-				}
+	case ConfigValidateCommand:
+		ValidateConfig(opts.root)
 
-				maxErrors, err = strconv.Atoi(args[i+1])
-				if err != nil {
-					panic(err)
-				}
+		return
 
-				i++
-			case "complete":
-				statesAllowed = map[pkg.State]struct{}{
-					pkg.Complete: {},
-				}
-			case "incomplete":
-				statesAllowed = map[pkg.State]struct{}{
-					pkg.Incomplete: {},
-				}
-			case "stub":
-				statesAllowed = map[pkg.State]struct{}{
-					pkg.Stub: {},
-				}
+	case NewPageCommand, NewPracticeCommand, NewChapterCommand:
+		New(opts.command, opts.args)
 
-			default:
-				if !rootFound {
-					root = arg
-					rootFound = true
-				} else {
-					courseWanted = arg
-				}
-			}
-		}
-	}
-
-	if len(statesAllowed) == 0 {
-		statesAllowed = map[pkg.State]struct{}{
-			pkg.Complete:   {},
-			pkg.Incomplete: {},
-			pkg.Stub:       {},
-		}
+		return
 	}
 
-	return action, root, statesAllowed, verbose, printIndex, printNonIndex, courseWanted, maxErrors, tagsWanted, checkExternal
-}
-
-func main() {
-	action, root, statesAllowed, verbose, printIndex, printNonIndex, courseWanted, maxErrors, tagsWanted, checkExternal := getArgs(os.Args)
+	config := loadConfig(opts.root)
 
 	// collect markdown files
-	files, err := findFiles(root, courseWanted, verbose)
+	files, err := findFiles(opts.root, opts.courseWanted, opts.verbose)
 	if err != nil {
-		panic("cannot find files in root: " + root + ", error: " + err.Error())
+		fmt.Fprintln(os.Stderr, "cannot find files in root:", opts.root+", error:", err)
+		os.Exit(exitUsageError)
 	}
 
-	if action == VersionCommand {
-		fmt.Println("Version:", Version)
+	// fetch markdown files
+	courses, count, problems := CrawlMarkdownFiles(files, opts.maxErrors, opts.tagsWanted, opts.verbose)
+	if opts.format == pkg.TextFormat {
+		fmt.Println("Processed", count, "markdown files.")
+	}
 
-		return
+	// files which could not be parsed are part of the report of the errors command, the other commands only list them
+	if opts.command != ErrorsCommand {
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, problem)
+		}
 	}
 
-	// fetch markdown files
-	courses, count := CrawlMarkdownFiles(files, maxErrors, tagsWanted, verbose)
-	fmt.Println("Processed", count, "markdown files.")
+	var records []pkg.Record
 
-	switch action {
+	switch opts.command {
 	case PrintCommand:
-		Print(count, courses, statesAllowed, printIndex, printNonIndex)
+		Print(count, courses, opts.statesAllowed, opts.printIndex, opts.printNonIndex)
 
 	case ErrorsCommand:
-		Errors(count, courses)
+		Errors(opts.root, count, courses, problems, opts.format)
 
 	case StatsCommand:
 		pkg.PrintStats(courses)
@@ -153,25 +86,57 @@ func main() {
 	case CheckPageOrderCommand:
 		CheckPageOrder(count, courses)
 
-	case CheckLinksCommand:
-		if courseWanted != "" {
-			fmt.Println("cannot check links for a specific course")
+	case FixCommand:
+		Fix(courses, opts.dryRun)
 
-			return
-		}
-		if len(tagsWanted) > 0 {
-			fmt.Println("cannot check links for a specific tag")
+	case RenumberCommand:
+		Renumber(courses, opts.dryRun)
 
-			return
+	case OrphansCommand:
+		records = Orphans(opts.root, courses, config.OrphanEntryPoints, opts.format)
+
+	case AssetsCommand:
+		records = Assets(opts.root, courses, opts.format)
+
+	case CheckLinksCommand:
+		var linkCache *pkg.LinkCache
+		if opts.checkExternal {
+			linkCache = loadLinkCache(opts.root, config, opts.refresh)
 		}
 
-		CheckLinks(count, courses, checkExternal, verbose)
+		records = CheckLinks(opts.root, count, courses, opts.checkExternal, opts.verbose, opts.format, config.SkipDomains, config.MaxDomains, linkCache)
+	}
 
-	default:
-		panic("unknown command: " + string(action))
+	if len(problems) > 0 || pkg.HasErrors(records) {
+		os.Exit(exitContentErrors)
 	}
 }
 
+func loadConfig(root string) pkg.Config {
+	config, _, err := pkg.LoadConfig(root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cannot load config in root:", root+", error:", err)
+		os.Exit(exitUsageError)
+	}
+
+	pkg.ApplyConfig(config)
+
+	return config
+}
+
+func loadLinkCache(root string, config pkg.Config, refresh bool) *pkg.LinkCache {
+	linkCache := pkg.NewLinkCache(filepath.Join(root, pkg.LinkCacheFileName), config.GetLinkCacheTTL())
+	if refresh {
+		return linkCache
+	}
+
+	if err := linkCache.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	return linkCache
+}
+
 func findFiles(root, courseWanted string, verbose bool) ([]string, error) {
 	if courseWanted == "" {
 		courseWanted = "**"
@@ -196,77 +161,106 @@ func findFiles(root, courseWanted string, verbose bool) ([]string, error) {
 
 const defaulMaxErrors = -1
 
-func CrawlMarkdownFiles(matches []string, maxErrors int, tagsWanted []string, verbose bool) (pkg.Courses, int) {
+type crawlResult struct {
+	filePath  string
+	course    string
+	chapter   string
+	fileName  string
+	content   pkg.Content
+	skipped   bool
+	wanted    bool
+	hasIssues bool
+	problems  []pkg.Issue
+}
+
+var crawlWorkers = runtime.NumCPU()
+
+func CrawlMarkdownFiles(matches []string, maxErrors int, tagsWanted []string, verbose bool) (pkg.Courses, int, []pkg.Record) {
 	if maxErrors < 0 {
 		maxErrors = math.MaxInt
 	}
 
+	// files are parsed in parallel, but each result has its own slot so that they can be consumed in order
+	results := make([]crawlResult, len(matches))
+	ready := make([]chan struct{}, len(matches))
+	for i := range ready {
+		ready[i] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	stop := make(chan struct{})
+
+	go func() {
+		defer close(jobs)
+
+		for i := range matches {
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range max(1, crawlWorkers) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i] = crawlMarkdownFile(matches[i], tagsWanted)
+				close(ready[i])
+			}
+		}()
+	}
+
 	result := make(pkg.Courses, 0, len(matches))
 
+	var problems []pkg.Record
 	var count, errCount int
 
-	for _, filePath := range matches {
+	for i := range matches {
 		if maxErrors > 0 && errCount >= maxErrors {
-			fmt.Println("Max errors reached, stopping")
+			fmt.Fprintln(os.Stderr, "Max errors reached, stopping")
 			break
 		}
 
-		parts := strings.Split(filePath, "/")
+		<-ready[i]
+		res := results[i]
 
-		if len(parts) < 3 {
-			fmt.Println("Skipping:", filePath)
+		if res.skipped {
+			fmt.Println("Skipping:", res.filePath)
 			continue
 		}
 
-		course := parts[len(parts)-3]
-		chapter := parts[len(parts)-2]
-		fileName := parts[len(parts)-1]
-
-		rawContent, err := os.ReadFile(filePath)
-		if err != nil {
-			panic("cannot open file: " + filePath)
-		}
+		if len(res.problems) > 0 {
+			for _, issue := range res.problems {
+				problems = append(problems, pkg.NewRecord(res.filePath, res.course, res.chapter, res.fileName, issue))
+			}
 
-		if len(rawContent) == 0 {
-			panic("empty file: " + filePath)
-		}
+			errCount++
 
-		content, err := pkg.ParseMarkdown(string(rawContent))
-		if err != nil {
-			panic("cannot parse markdown: " + filePath + ", err: " + err.Error())
+			continue
 		}
 
-		if len(tagsWanted) > 0 {
-			found := false
-
-			for _, tag := range content.Tags {
-				for _, tagWanted := range tagsWanted {
-					if tag == tagWanted {
-						found = true
-						break
-					}
-
-				}
-
-				if found {
-					break
-				}
-			}
-
-			if !found {
-				continue
-			}
+		if !res.wanted {
+			continue
 		}
 
-		result = result.Add(filePath, course, chapter, fileName, content)
+		result = result.Add(res.filePath, res.course, res.chapter, res.fileName, res.content)
 
-		if len(content.GetIssues(filePath, course, chapter, fileName)) > 0 {
+		if res.hasIssues {
 			errCount++
 		}
 
 		count++
 	}
 
+	close(stop)
+	wg.Wait()
+
 	if verbose {
 		fmt.Println()
 		fmt.Println("Courses:")
@@ -275,7 +269,65 @@ func CrawlMarkdownFiles(matches []string, maxErrors int, tagsWanted []string, ve
 		}
 	}
 
-	return result, count
+	return result, count, problems
+}
+
+func crawlMarkdownFile(filePath string, tagsWanted []string) crawlResult {
+	parts := strings.Split(filePath, "/")
+
+	if len(parts) < 3 {
+		return crawlResult{filePath: filePath, skipped: true}
+	}
+
+	res := crawlResult{
+		filePath: filePath,
+		course:   parts[len(parts)-3],
+		chapter:  parts[len(parts)-2],
+		fileName: parts[len(parts)-1],
+	}
+
+	rawContent, err := os.ReadFile(filePath)
+	if err != nil {
+		res.problems = append(res.problems, pkg.NewIssue(pkg.RuleFileUnreadable, "file could not be read: "+err.Error()))
+
+		return res
+	}
+
+	if len(rawContent) == 0 {
+		res.problems = append(res.problems, pkg.NewIssue(pkg.RuleFileEmpty, "file is empty"))
+
+		return res
+	}
+
+	res.content, err = pkg.ParseMarkdown(string(rawContent))
+	if err != nil {
+		res.problems = append(res.problems, pkg.NewIssue(pkg.RuleFrontMatterInvalid, "front matter could not be split: "+err.Error()))
+
+		return res
+	}
+
+	res.wanted = hasWantedTag(res.content.Tags, tagsWanted)
+	if res.wanted {
+		res.hasIssues = len(res.content.GetIssues(filePath, res.course, res.chapter, res.fileName)) > 0
+	}
+
+	return res
+}
+
+func hasWantedTag(tags, tagsWanted []string) bool {
+	if len(tagsWanted) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		for _, tagWanted := range tagsWanted {
+			if tag == tagWanted {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func Print(count int, courses pkg.Courses, statesAllowed map[pkg.State]struct{}, printIndex, printNonIndex bool) {
@@ -294,9 +346,9 @@ func CheckChapterOrder(count int, courses pkg.Courses) {
 
 		fmt.Println(course.Course)
 		fmt.Println(strings.Repeat("=", len(course.Course)))
-		fmt.Println(strings.Join(issues, "\n"))
+		fmt.Println(joinMessages(issues))
 		fmt.Println()
-		fmt.Println(strings.Join(issues, "\n"))
+		fmt.Println(joinMessages(issues))
 		fmt.Println()
 	}
 }
@@ -312,26 +364,36 @@ func CheckPageOrder(count int, courses pkg.Courses) {
 		fmt.Println(course.Course)
 		fmt.Println(strings.Repeat("=", len(course.Course)))
 		fmt.Println()
-		fmt.Println(strings.Join(issues, "\n"))
+		fmt.Println(joinMessages(issues))
 		fmt.Println()
 	}
 }
 
+func joinMessages(records []pkg.Record) string {
+	messages := make([]string, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, record.Message)
+	}
+
+	return strings.Join(messages, "\n")
+}
+
 var linkRegex = regexp.MustCompile(`https?://([^//]+)/?[^ ]*`)
 
-func CheckLinks(count int, courses pkg.Courses, checkExternal, verbose bool) {
+func CheckLinks(root string, count int, courses pkg.Courses, checkExternal, verbose bool, format pkg.Format, skipDomains []string, maxDomains int, linkCache *pkg.LinkCache) []pkg.Record {
 	fileLinks := make(map[string][]string)
 	internalLinks := sm.New[string, []string]()
 	externalLinks := sm.New[string, *sm.SortedMap[string, []string]]()
 	for _, course := range courses {
-		for page, link := range course.GetLinks() {
+		for page, link := range course.GetLinks(root) {
 			matches := linkRegex.FindStringSubmatch(link)
 
 			if len(matches) < 2 {
-				ext := filepath.Ext(link)
+				linkPath, _ := pkg.SplitFragment(link)
+				ext := filepath.Ext(linkPath)
 
 				if ext != "" {
-					fileLinks[link] = append(fileLinks[link], page)
+					fileLinks[linkPath] = append(fileLinks[linkPath], page)
 				} else if internalLinks.Has(link) {
 					internalLinks.Set(link, append(internalLinks.MustGet(link), page))
 				} else {
@@ -341,6 +403,8 @@ func CheckLinks(count int, courses pkg.Courses, checkExternal, verbose bool) {
 				continue
 			}
 
+			// fragments are never sent to the server
+			link, _ = pkg.SplitFragment(link)
 			domain := matches[1]
 
 			if !externalLinks.Has(domain) {
@@ -357,79 +421,196 @@ func CheckLinks(count int, courses pkg.Courses, checkExternal, verbose bool) {
 		}
 	}
 
-	checkInternalLinks(internalLinks, courses, verbose)
-	checkExternalLinks(externalLinks, checkExternal, verbose)
-	checkFileLinks(fileLinks)
+	var out io.Writer = os.Stdout
+	if format != pkg.TextFormat {
+		out = io.Discard
+	}
+
+	var records []pkg.Record
+	if slices.ContainsFunc(internalLinkRules, pkg.IsRuleEnabled) {
+		records = append(records, checkInternalLinks(out, root, internalLinks, courses, verbose)...)
+	}
+	if pkg.IsRuleEnabled(pkg.RuleRefNotFound) || pkg.IsRuleEnabled(pkg.RuleRefAmbiguous) {
+		records = append(records, checkRefLinks(out, root, courses)...)
+	}
+	if pkg.IsRuleEnabled(pkg.RuleAliasCollision) {
+		records = append(records, checkAliases(out, root, courses)...)
+	}
+	if slices.ContainsFunc(externalLinkRules, pkg.IsRuleEnabled) {
+		records = append(records, checkExternalLinks(out, courses.GetPages(), externalLinks, checkExternal, verbose, skipDomains, maxDomains, linkCache)...)
+	}
+	if pkg.IsRuleEnabled(pkg.RuleFileLinkNotFound) {
+		records = append(records, checkFileLinks(out, root, courses.GetPages(), fileLinks)...)
+	}
+
+	if format != pkg.TextFormat {
+		writeRecords(format, root, records)
+	}
+
+	return records
 }
 
-func checkInternalLinks(links *sm.SortedMap[string, []string], courses pkg.Courses, verbose bool) {
-	validInternalLinks := courses.GetValidInternalLinks()
+func newLinkRecord(pages map[string]pkg.Page, source string, issue pkg.Issue) pkg.Record {
+	filePath, row, col := pkg.SplitLinkSource(source)
+	page := pages[filePath]
+
+	record := pkg.NewRecord(filePath, page.Course, page.Chapter, filepath.Base(filePath), issue)
+	record.Line = row
+	record.Column = col
+
+	return record
+}
+
+var internalLinkRules = []pkg.Rule{
+	pkg.RuleInternalLinkNotFound,
+	pkg.RuleInternalLinkAnchor,
+	pkg.RuleInternalLinkSlash,
+}
+
+var externalLinkRules = []pkg.Rule{
+	pkg.RuleExternalLinkStatus,
+	pkg.RuleExternalLinkMoved,
+	pkg.RuleExternalRedirectLoop,
+	pkg.RuleExternalRedirectDomain,
+	pkg.RuleExternalLinkError,
+}
+
+func checkInternalLinks(out io.Writer, root string, links *sm.SortedMap[string, []string], courses pkg.Courses, verbose bool) []pkg.Record {
+	pages := courses.GetPages()
+	validInternalLinks := courses.GetValidInternalLinks(root)
+	anchors := courses.GetAnchors(root)
+
+	var records []pkg.Record
+
+	notFound, anchorsNotFound, slashesMissing := 0, 0, 0
+	for link, sources := range links.Items() {
+		linkPath, fragment := pkg.SplitFragment(link)
+
+		// Hugo serves pages with a trailing slash, links without one only work thanks to a redirect
+		slashMissing := false
+		if _, ok := validInternalLinks[linkPath]; !ok {
+			linkPath += "/"
+			slashMissing = true
+		}
+
+		if _, ok := validInternalLinks[linkPath]; !ok {
+			if !pkg.IsRuleEnabled(pkg.RuleInternalLinkNotFound) {
+				continue
+			}
+
+			notFound++
+			fmt.Fprintf(out, "- '%s' NOT FOUND\n", link)
+			for _, source := range sources {
+				fmt.Fprintf(out, "    - %s\n", source)
+				records = append(records, newLinkRecord(pages, source, pkg.NewIssue(pkg.RuleInternalLinkNotFound, fmt.Sprintf("internal link not found: %s", link))))
+			}
 
-	notFound := 0
-	for link, pages := range links.Items() {
-		if _, ok := validInternalLinks[link]; ok {
 			continue
 		}
 
-		if _, ok := validInternalLinks[link+"/"]; ok {
+		if slashMissing && pkg.IsRuleEnabled(pkg.RuleInternalLinkSlash) {
+			slashesMissing++
+			fmt.Fprintf(out, "- '%s' MISSING TRAILING SLASH\n", link)
+			for _, source := range sources {
+				fmt.Fprintf(out, "    - %s\n", source)
+				records = append(records, newLinkRecord(pages, source, pkg.NewIssue(pkg.RuleInternalLinkSlash, fmt.Sprintf("internal link is missing the trailing slash: %s", link))))
+			}
+		}
+
+		if fragment == "" || !pkg.IsRuleEnabled(pkg.RuleInternalLinkAnchor) {
 			continue
 		}
 
-		notFound++
-		fmt.Printf("- '%s' NOT FOUND\n", link)
-		for _, page := range pages {
-			fmt.Printf("    - %s\n", page)
+		if _, ok := anchors[linkPath][fragment]; ok {
+			continue
+		}
+
+		anchorsNotFound++
+		fmt.Fprintf(out, "- '%s' ANCHOR NOT FOUND\n", link)
+		for _, source := range sources {
+			fmt.Fprintf(out, "    - %s\n", source)
+			records = append(records, newLinkRecord(pages, source, pkg.NewIssue(pkg.RuleInternalLinkAnchor, fmt.Sprintf("internal link anchor not found: %s", link))))
 		}
 	}
 
 	if notFound > 0 {
-		fmt.Println("Not found", notFound, "internal links.")
+		fmt.Fprintln(out, "Not found", notFound, "internal links.")
 	} else {
-		fmt.Println("All internal links found.")
+		fmt.Fprintln(out, "All internal links found.")
+	}
+
+	if anchorsNotFound > 0 {
+		fmt.Fprintln(out, "Not found", anchorsNotFound, "internal link anchors.")
+	}
+
+	if slashesMissing > 0 {
+		fmt.Fprintln(out, "Missing trailing slash in", slashesMissing, "internal links.")
 	}
 
 	if verbose {
 		for link := range validInternalLinks {
-			fmt.Printf("Found link: '%s'\n", link)
+			fmt.Fprintf(out, "Found link: '%s'\n", link)
 		}
 	}
+
+	return records
 }
 
-var skipDomains = []string{
-	"codeforces.com",
-	"developer.android.com",
-	"leetcode.com",
-	"linux.die.net",
-	"marketplace.visualstudio.com",
-	"udemy.com",
-	"youtube.com",
-	"www.amazon.com",
-	"www.canva.com",
-	"www.cloudflare.com",
-	"www.java.com",
-	"www.linux.org",
-	"www.make.com",
-	"www.mercurial-scm.org",
-	"www.skillshare.com",
-	"www.softwaretestinghelp.com",
-	"www.whatsapp.com",
-	"www.youtube.com",
+func checkRefLinks(out io.Writer, root string, courses pkg.Courses) []pkg.Record {
+	records := courses.GetRefIssues(root)
+
+	for _, record := range records {
+		fmt.Fprintf(out, "- %s\n", record)
+	}
+
+	if len(records) > 0 {
+		fmt.Fprintln(out, "Found", len(records), "broken refs.")
+	} else {
+		fmt.Fprintln(out, "All refs resolved.")
+	}
+
+	return records
 }
 
-func checkExternalLinks(links *sm.SortedMap[string, *sm.SortedMap[string, []string]], checkExternal, verbose bool) {
+func checkAliases(out io.Writer, root string, courses pkg.Courses) []pkg.Record {
+	records := courses.GetAliasIssues(root)
+
+	for _, record := range records {
+		fmt.Fprintf(out, "- %s\n", record)
+	}
+
+	if len(records) > 0 {
+		fmt.Fprintln(out, "Found", len(records), "pages claiming URLs of other pages.")
+	}
+
+	return records
+}
+
+func checkExternalLinks(out io.Writer, pages map[string]pkg.Page, links *sm.SortedMap[string, *sm.SortedMap[string, []string]], checkExternal, verbose bool, skipDomains []string, maxDomains int, linkCache *pkg.LinkCache) []pkg.Record {
 	if !checkExternal {
-		return
+		return nil
+	}
+
+	if linkCache != nil {
+		defer func() {
+			if err := linkCache.Save(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
 	}
 
 	var wg sync.WaitGroup
 	var lock sync.Mutex
+	var records []pkg.Record
+
+	domainSlots := make(chan struct{}, max(maxDomains, 1))
 
 	for domain, domainLinks := range links.Items() {
 		skip := false
 		for _, skipDomain := range skipDomains {
 			if domain == skipDomain {
 				skip = true
-				fmt.Println("Skipping domain:", domain)
+				fmt.Fprintln(out, "Skipping domain:", domain)
 			}
 		}
 		if skip {
@@ -442,49 +623,95 @@ func checkExternalLinks(links *sm.SortedMap[string, *sm.SortedMap[string, []stri
 		go func() {
 			defer wg.Done()
 
-			domainClient := pkg.NewDomainClient(domain, 5*time.Second, 3, 1*time.Second)
+			results, urls := getCachedResults(linkCache, domainLinks.Keys())
+			if verbose && len(results) > 0 {
+				fmt.Fprintf(out, "Domain: %s, Cached: %d\n", domain, len(results))
+			}
+
+			if len(urls) > 0 {
+				domainClient := pkg.NewDomainClient(domain, 5*time.Second, 3, 1*time.Second)
+
+				domainSlots <- struct{}{}
+				fetched := domainClient.FetchPages(urls)
+				<-domainSlots
+
+				for _, result := range fetched {
+					if linkCache != nil {
+						linkCache.Set(result)
+					}
+				}
+
+				results = append(results, fetched...)
+			}
 
-			results := domainClient.FetchPages(domainLinks.Keys())
 			lock.Lock()
 			defer lock.Unlock()
 
 			for _, result := range results {
-				if result.Code == 200 {
-					continue
-				}
+				for _, issue := range result.Issues() {
+					if !pkg.IsRuleEnabled(issue.Rule) {
+						continue
+					}
+
+					fmt.Fprintf(out, "Domain: %s, %s\n", domain, issue.Message)
+					for _, content := range domainLinks.MustGet(result.URL) {
+						fmt.Fprintln(out, "  -", content)
+						written = true
 
-				fmt.Printf("Domain: %s, Status code: %d, URL: %s\n", domain, result.Code, result.URL)
-				for _, content := range domainLinks.MustGet(result.URL) {
-					fmt.Println("  -", content)
-					written = true
+						records = append(records, newLinkRecord(pages, content, issue))
+					}
 				}
 			}
 
 			if verbose {
-				fmt.Printf("Domain: %s, Count: %d\n\n", domain, domainLinks.Len())
+				fmt.Fprintf(out, "Domain: %s, Count: %d\n\n", domain, domainLinks.Len())
 			}
 
 			if verbose || written {
-				fmt.Println()
+				fmt.Fprintln(out)
 			}
 		}()
 	}
 
 	wg.Wait()
+
+	return records
+}
+
+func getCachedResults(linkCache *pkg.LinkCache, urls []string) ([]pkg.Result, []string) {
+	if linkCache == nil {
+		return nil, urls
+	}
+
+	var results []pkg.Result
+	var missing []string
+	for _, url := range urls {
+		if result, ok := linkCache.Get(url); ok {
+			results = append(results, result)
+
+			continue
+		}
+
+		missing = append(missing, url)
+	}
+
+	return results, missing
 }
 
-func checkFileLinks(links map[string][]string) {
+func checkFileLinks(out io.Writer, root string, pages map[string]pkg.Page, links map[string][]string) []pkg.Record {
+	var records []pkg.Record
+
 	found := 0
 	notFound := 0
-	for link, pages := range links {
-		filePath := "static/" + link
+	for link, sources := range links {
+		filePath := filepath.Join(root, "static", link)
 		if _, err := os.Stat(filePath); err == nil {
 			found++
 
 			continue
 		}
 
-		filePath = "content/" + link
+		filePath = filepath.Join(root, "content", link)
 
 		if _, err := os.Stat(filePath); err == nil {
 			found++
@@ -494,41 +721,356 @@ func checkFileLinks(links map[string][]string) {
 
 		notFound++
 
-		fmt.Printf("- %s NOT FOUND\n", link)
-		for _, page := range pages {
-			fmt.Printf("    - %s\n", page)
+		fmt.Fprintf(out, "- %s NOT FOUND\n", link)
+		for _, source := range sources {
+			fmt.Fprintf(out, "    - %s\n", source)
+			records = append(records, newLinkRecord(pages, source, pkg.NewIssue(pkg.RuleFileLinkNotFound, fmt.Sprintf("file link not found: %s", link))))
 		}
 	}
 
 	if found > 0 {
-		fmt.Println("Found", found, "file links.")
+		fmt.Fprintln(out, "Found", found, "file links.")
 	}
 	if notFound > 0 {
-		fmt.Println("Not found", notFound, "file links.")
+		fmt.Fprintln(out, "Not found", notFound, "file links.")
 	}
+
+	return records
 }
 
-func Errors(count int, courses pkg.Courses) {
-	errorsFound := 0
-	files := make(map[string]struct{})
+func Orphans(root string, courses pkg.Courses, entryPoints []string, format pkg.Format) []pkg.Record {
+	records := courses.GetOrphanIssues(root, entryPoints)
+
+	if format != pkg.TextFormat {
+		writeRecords(format, root, records)
+
+		return records
+	}
+
+	for _, record := range records {
+		fmt.Println(record)
+	}
+
+	if len(records) > 0 {
+		fmt.Println("Found", len(records), "orphan pages.")
+	} else {
+		fmt.Println("No orphan pages found.")
+	}
+
+	return records
+}
+
+func Assets(root string, courses pkg.Courses, format pkg.Format) []pkg.Record {
+	assets, err := pkg.FindAssets(root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsageError)
+	}
+
+	records := courses.GetAssetIssues(root, assets)
+
+	if format != pkg.TextFormat {
+		writeRecords(format, root, records)
+
+		return records
+	}
+
+	unreferenced := 0
+	for _, record := range records {
+		fmt.Println(record)
+
+		if record.Rule == pkg.RuleAssetUnreferenced {
+			unreferenced++
+		}
+	}
+
+	fmt.Println("Found", len(assets), "assets,", unreferenced, "of them unreferenced.")
+
+	return records
+}
+
+func Errors(root string, count int, courses pkg.Courses, problems []pkg.Record, format pkg.Format) {
+	errors := append(problems, courses.GetErrors()...)
+
+	if format == pkg.SarifFormat {
+		// code scanning shows a single report per tool, so the order checks are included as well
+		errors = append(errors, courses.GetOrderIssues()...)
+	}
+
+	writeRecords(format, root, errors)
+
+	if pkg.HasErrors(errors) {
+		os.Exit(exitContentErrors)
+	}
+}
+
+func Fix(courses pkg.Courses, dryRun bool) {
+	fixed, failed := 0, 0
 
 	for _, course := range courses {
-		errors := course.GetErrors()
-		if len(errors) == 0 {
-			continue
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				rawContent, err := os.ReadFile(page.FileName)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "cannot read file:", page.FileName+", error:", err)
+					failed++
+
+					continue
+				}
+
+				fix, err := page.GetFix(string(rawContent))
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "cannot fix file:", page.FileName+", error:", err)
+					failed++
+
+					continue
+				}
+
+				if fix.IsEmpty() {
+					continue
+				}
+
+				fixed++
+
+				if dryRun {
+					fmt.Print(fix.Diff())
+
+					continue
+				}
+
+				for _, change := range fix.Changes {
+					fmt.Println(page.FileName, "-", change)
+				}
+
+				if err := fix.Apply(); err != nil {
+					fmt.Fprintln(os.Stderr, "cannot fix file:", page.FileName+", error:", err)
+					failed++
+				}
+			}
 		}
+	}
 
-		errorsFound += len(errors)
+	if dryRun {
+		fmt.Println("Would fix", fixed, "files.")
+	} else {
+		fmt.Println("Fixed", fixed, "files.")
+	}
+
+	if failed > 0 {
+		os.Exit(exitContentErrors)
+	}
+}
+
+func Renumber(courses pkg.Courses, dryRun bool) {
+	var fixes []pkg.PageFix
+	failed := 0
 
-		for _, str := range errors {
-			files[strings.Split(str, " - ")[0]] = struct{}{}
-			fmt.Println(str)
+	for _, course := range courses {
+		chapterWeights := course.GetChapterWeights()
+
+		for _, chapter := range course.Chapters {
+			pageWeights := chapter.GetPageWeights()
+
+			for _, page := range chapter.Pages {
+				weight, ok := pageWeights[page.FileName]
+				if !ok {
+					weight, ok = chapterWeights[page.FileName]
+				}
+				if !ok {
+					continue
+				}
+
+				rawContent, err := os.ReadFile(page.FileName)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "cannot read file:", page.FileName+", error:", err)
+					failed++
+
+					continue
+				}
+
+				fix, err := page.GetWeightFix(string(rawContent), weight)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "cannot renumber file:", page.FileName+", error:", err)
+					failed++
+
+					continue
+				}
+
+				if !fix.IsEmpty() {
+					fixes = append(fixes, fix)
+				}
+			}
+		}
+	}
+
+	for _, fix := range fixes {
+		for _, change := range fix.Changes {
+			fmt.Println(fix.FilePath, "-", change)
+		}
+	}
+
+	if dryRun {
+		fmt.Println("Would renumber", len(fixes), "files.")
+	} else {
+		renumbered := 0
+
+		for _, fix := range fixes {
+			if err := fix.Apply(); err != nil {
+				fmt.Fprintln(os.Stderr, "cannot renumber file:", fix.FilePath+", error:", err)
+				failed++
+
+				continue
+			}
+
+			renumbered++
+		}
+
+		fmt.Println("Renumbered", renumbered, "files.")
+	}
+
+	if failed > 0 {
+		fmt.Println("Failed to renumber", failed, "files.")
+	}
+
+	if failed > 0 {
+		os.Exit(exitContentErrors)
+	}
+}
+
+func getNewArgs(action Command, args []string) (string, string, string, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return "", "", "", errors.New("expected a target, a title and optionally a root")
+	}
+
+	target := strings.Trim(args[0], "/")
+	title := strings.TrimSpace(args[1])
+
+	root := "."
+	if len(args) > 2 {
+		root = args[2]
+	}
+
+	if title == "" {
+		return "", "", "", errors.New("title must not be empty")
+	}
+
+	parts := strings.Split(target, "/")
+	if action == NewChapterCommand && len(parts) != 1 {
+		return "", "", "", fmt.Errorf("target must be a course, got: %s", target)
+	}
+	if action != NewChapterCommand && len(parts) != 2 {
+		return "", "", "", fmt.Errorf("target must be a course and a chapter (course/chapter), got: %s", target)
+	}
+
+	return target, title, root, nil
+}
+
+func New(action Command, args []string) {
+	target, title, root, err := getNewArgs(action, args)
+	if err != nil {
+		usageError(action, err)
+	}
+
+	loadConfig(root)
+
+	course, chapter, _ := strings.Cut(target, "/")
+
+	files, err := findFiles(root, course, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cannot find files in root:", root+", error:", err)
+		os.Exit(exitUsageError)
+	}
+
+	courses, _, _ := CrawlMarkdownFiles(files, defaulMaxErrors, nil, false)
+
+	var filePath, content string
+	if action == NewChapterCommand {
+		weight := 1
+		for _, c := range courses {
+			if c.Course == course {
+				weight = c.NextChapterWeight()
+			}
+		}
+
+		dirName, skeleton := pkg.NewChapterSkeleton(title, weight)
+		filePath, content = filepath.Join(root, "content", course, dirName, "_index.md"), skeleton
+	} else {
+		var found *pkg.Chapter
+		for _, c := range courses {
+			for _, ch := range c.Chapters {
+				if c.Course == course && ch.Chapter == chapter {
+					found = ch
+				}
+			}
 		}
+
+		if found == nil {
+			fmt.Fprintln(os.Stderr, "chapter not found:", target)
+			os.Exit(exitUsageError)
+		}
+
+		fileName, skeleton := pkg.NewPageSkeleton(title, found.NextPageWeight(), action == NewPracticeCommand)
+		filePath, content = filepath.Join(root, "content", course, chapter, fileName), skeleton
+	}
+
+	if _, err := os.Stat(filePath); err == nil {
+		fmt.Fprintln(os.Stderr, "file already exists:", filePath)
+		os.Exit(exitUsageError)
 	}
 
-	fmt.Println("Found", errorsFound, "errors in", len(files), "files.")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "cannot create directory:", filepath.Dir(filePath)+", error:", err)
+		os.Exit(exitUsageError)
+	}
+
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "cannot write file:", filePath+", error:", err)
+		os.Exit(exitUsageError)
+	}
+
+	fmt.Println("Created", filePath)
+}
+
+func writeRecords(format pkg.Format, root string, records []pkg.Record) {
+	if err := pkg.WriteRecords(os.Stdout, format, records, root, Version); err != nil {
+		fmt.Fprintln(os.Stderr, "cannot write output:", err)
+		os.Exit(exitUsageError)
+	}
+}
+
+func ValidateConfig(root string) {
+	configPath := filepath.Join(root, pkg.ConfigFileName)
+	if _, err := os.Stat(configPath); err != nil {
+		fmt.Println("No config file found at", configPath+", using defaults.")
+
+		return
+	}
+
+	config, unknownKeys, err := pkg.LoadConfig(root)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(exitUsageError)
+	}
+
+	problems := config.Validate()
+	for _, key := range unknownKeys {
+		problems = append(problems, "unknown key: "+key)
+	}
+
+	for _, problem := range problems {
+		fmt.Println("-", problem)
+	}
+
+	if len(problems) > 0 {
+		fmt.Println("Found", len(problems), "problems in", configPath+".")
+		os.Exit(exitUsageError)
+	}
+
+	fmt.Println("Config file is valid:", configPath)
+}
 
-	if errorsFound > 0 {
-		os.Exit(1)
+func Rules() {
+	for _, info := range pkg.Rules() {
+		fmt.Printf("%s  %-24s  %-7s  %s\n", info.Code, info.Rule, info.Severity, info.Description)
 	}
 }
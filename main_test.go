@@ -1,10 +1,19 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/devwithpeet/content-checker/pkg"
+	sm "github.com/peteraba/sortedmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // insert test for getArgs
@@ -28,6 +37,10 @@ func Test_getArgs(t *testing.T) {
 		wantMaxErrors     int
 		wantTagsWanted    []string
 		wantCheckExternal bool
+		wantRefresh       bool
+		wantFormat        pkg.Format
+		wantDryRun        bool
+		wantErr           bool
 	}{
 		{
 			name:              "version",
@@ -41,6 +54,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "",
 			wantMaxErrors:     -1,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "print",
@@ -54,6 +68,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "",
 			wantMaxErrors:     -1,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "print hello",
@@ -67,6 +82,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "",
 			wantMaxErrors:     -1,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "print hello --verbose",
@@ -80,6 +96,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "",
 			wantMaxErrors:     -1,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "print . --verbose --max-errors 12",
@@ -93,6 +110,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "",
 			wantMaxErrors:     12,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "print . --verbose --max-errors 12 a1.1",
@@ -106,10 +124,11 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "a1.1",
 			wantMaxErrors:     12,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
-			name:        "print . --verbose --max-errors 12 stub a1.1",
-			args:        []string{"", "print", ".", "--verbose", "--max-errors", "12", "stub", "a1.1"},
+			name:        "print . --verbose --max-errors 12 --state stub a1.1",
+			args:        []string{"", "print", ".", "--verbose", "--max-errors", "12", "--state", "stub", "a1.1"},
 			wantCommand: PrintCommand,
 			wantPath:    ".",
 			wantStatesAllowed: map[pkg.State]struct{}{
@@ -121,10 +140,11 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "a1.1",
 			wantMaxErrors:     12,
 			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
-			name:        "print . --verbose --max-errors 12 --tags 'foo,bar' stub a1.1",
-			args:        []string{"", "print", ".", "--verbose", "--max-errors", "12", "--tags", "foo,bar", "stub", "a1.1"},
+			name:        "print . --verbose --max-errors 12 --tags 'foo,bar' --state=stub a1.1",
+			args:        []string{"", "print", ".", "--verbose", "--max-errors", "12", "--tags", "foo,bar", "--state=stub", "a1.1"},
 			wantCommand: PrintCommand,
 			wantPath:    ".",
 			wantStatesAllowed: map[pkg.State]struct{}{
@@ -136,6 +156,7 @@ func Test_getArgs(t *testing.T) {
 			wantCourseWanted:  "a1.1",
 			wantMaxErrors:     12,
 			wantTagsWanted:    []string{"foo", "bar"},
+			wantFormat:        pkg.TextFormat,
 		},
 		{
 			name:              "check-links . --check-external",
@@ -150,25 +171,457 @@ func Test_getArgs(t *testing.T) {
 			wantMaxErrors:     -1,
 			wantTagsWanted:    []string{},
 			wantCheckExternal: true,
+			wantFormat:        pkg.TextFormat,
+		},
+		{
+			name:              "check-links . --check-external --refresh",
+			args:              []string{"", "check-links", ".", "--check-external", "--refresh"},
+			wantCommand:       CheckLinksCommand,
+			wantPath:          ".",
+			wantStatesAllowed: defaultStatesAllowed,
+			wantVerbose:       false,
+			wantPrintIndex:    false,
+			wantPrintNonIndex: true,
+			wantCourseWanted:  "",
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantCheckExternal: true,
+			wantRefresh:       true,
+			wantFormat:        pkg.TextFormat,
+		},
+		{
+			name:              "errors . --format json",
+			args:              []string{"", "errors", ".", "--format", "json"},
+			wantCommand:       ErrorsCommand,
+			wantPath:          ".",
+			wantStatesAllowed: defaultStatesAllowed,
+			wantVerbose:       false,
+			wantPrintIndex:    false,
+			wantPrintNonIndex: true,
+			wantCourseWanted:  "",
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantFormat:        pkg.JSONFormat,
+		},
+		{
+			name:              "fix --dry-run",
+			args:              []string{"", "fix", "--dry-run"},
+			wantCommand:       FixCommand,
+			wantPath:          ".",
+			wantStatesAllowed: defaultStatesAllowed,
+			wantVerbose:       false,
+			wantPrintIndex:    false,
+			wantPrintNonIndex: true,
+			wantCourseWanted:  "",
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
+			wantDryRun:        true,
+		},
+		{
+			name:        "print --state complete -state stub,incomplete --without-non-index --with-index",
+			args:        []string{"", "print", "--state", "complete", "-state", "stub,incomplete", "--without-non-index", "--with-index"},
+			wantCommand: PrintCommand,
+			wantPath:    ".",
+			wantStatesAllowed: map[pkg.State]struct{}{
+				pkg.Complete:   {},
+				pkg.Incomplete: {},
+				pkg.Stub:       {},
+			},
+			wantVerbose:       false,
+			wantPrintIndex:    true,
+			wantPrintNonIndex: false,
+			wantCourseWanted:  "",
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
+		},
+		{
+			name:    "unknown state",
+			args:    []string{"", "print", "--state", "done"},
+			wantErr: true,
+		},
+		{
+			name:              "orphans . --format json",
+			args:              []string{"", "orphans", ".", "--format", "json"},
+			wantCommand:       OrphansCommand,
+			wantPath:          ".",
+			wantStatesAllowed: defaultStatesAllowed,
+			wantPrintNonIndex: true,
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantFormat:        pkg.JSONFormat,
+		},
+		{
+			name:    "flag of another command",
+			args:    []string{"", "stats", "--check-external"},
+			wantErr: true,
+		},
+		{
+			name:    "too many arguments",
+			args:    []string{"", "check-links", ".", "go"},
+			wantErr: true,
+		},
+		{
+			name:    "help",
+			args:    []string{"", "errors", "--help"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"", "foo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid max errors",
+			args:    []string{"", "errors", "--max-errors", "many"},
+			wantErr: true,
+		},
+		{
+			name:    "missing format",
+			args:    []string{"", "errors", "--format"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"", "errors", "--format", "xml"},
+			wantErr: true,
+		},
+		{
+			name:              "config validate content",
+			args:              []string{"", "config", "validate", "content"},
+			wantCommand:       ConfigValidateCommand,
+			wantPath:          "content",
+			wantStatesAllowed: defaultStatesAllowed,
+			wantVerbose:       false,
+			wantPrintIndex:    false,
+			wantPrintNonIndex: true,
+			wantCourseWanted:  "",
+			wantMaxErrors:     -1,
+			wantTagsWanted:    []string{},
+			wantFormat:        pkg.TextFormat,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// execute
-			command, path, statesAllowed, verbose, printIndex, printNonIndex, courseWanted, maxErrors, tagsWanted, checkExternal := getArgs(tt.args)
+			opts, err := getArgs(tt.args)
 
 			// verify
-			assert.Equal(t, tt.wantCommand, command, "command")
-			assert.Equal(t, tt.wantPath, path, "path")
-			assert.Equal(t, tt.wantStatesAllowed, statesAllowed, "statesAllowed")
-			assert.Equal(t, tt.wantVerbose, verbose, "verbose")
-			assert.Equal(t, tt.wantPrintIndex, printIndex, "printIndex")
-			assert.Equal(t, tt.wantPrintNonIndex, printNonIndex, "printNonIndex")
-			assert.Equal(t, tt.wantCourseWanted, courseWanted, "courseWanted")
-			assert.Equal(t, tt.wantMaxErrors, maxErrors, "maxErrors")
-			assert.Equal(t, tt.wantTagsWanted, tagsWanted, "tagsWanted")
-			assert.Equal(t, tt.wantCheckExternal, checkExternal, "checkExternal")
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCommand, opts.command, "command")
+			assert.Equal(t, tt.wantPath, opts.root, "path")
+			assert.Equal(t, tt.wantStatesAllowed, opts.statesAllowed, "statesAllowed")
+			assert.Equal(t, tt.wantVerbose, opts.verbose, "verbose")
+			assert.Equal(t, tt.wantPrintIndex, opts.printIndex, "printIndex")
+			assert.Equal(t, tt.wantPrintNonIndex, opts.printNonIndex, "printNonIndex")
+			assert.Equal(t, tt.wantCourseWanted, opts.courseWanted, "courseWanted")
+			assert.Equal(t, tt.wantMaxErrors, opts.maxErrors, "maxErrors")
+			assert.Equal(t, tt.wantTagsWanted, opts.tagsWanted, "tagsWanted")
+			assert.Equal(t, tt.wantCheckExternal, opts.checkExternal, "checkExternal")
+			assert.Equal(t, tt.wantRefresh, opts.refresh, "refresh")
+			assert.Equal(t, tt.wantFormat, opts.format, "format")
+			assert.Equal(t, tt.wantDryRun, opts.dryRun, "dryRun")
 		})
 	}
 
 }
+
+func Test_getNewArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     Command
+		args       []string
+		wantTarget string
+		wantTitle  string
+		wantRoot   string
+		wantErr    bool
+	}{
+		{
+			name:       "page",
+			action:     NewPageCommand,
+			args:       []string{"go/basics/", "Maps & Slices"},
+			wantTarget: "go/basics",
+			wantTitle:  "Maps & Slices",
+			wantRoot:   ".",
+		},
+		{
+			name:       "chapter with root",
+			action:     NewChapterCommand,
+			args:       []string{"go", "Web I.", "site"},
+			wantTarget: "go",
+			wantTitle:  "Web I.",
+			wantRoot:   "site",
+		},
+		{
+			name:    "page without chapter",
+			action:  NewPageCommand,
+			args:    []string{"go", "Maps"},
+			wantErr: true,
+		},
+		{
+			name:    "chapter with chapter",
+			action:  NewChapterCommand,
+			args:    []string{"go/basics", "Web"},
+			wantErr: true,
+		},
+		{
+			name:    "missing title",
+			action:  NewPracticeCommand,
+			args:    []string{"go/basics"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			target, title, root, err := getNewArgs(tt.action, tt.args)
+
+			// verify
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTarget, target, "target")
+			assert.Equal(t, tt.wantTitle, title, "title")
+			assert.Equal(t, tt.wantRoot, root, "root")
+		})
+	}
+}
+
+func TestCrawlMarkdownFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "content", "go", "basics")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	var files []string
+	for i := 1; i <= 20; i++ {
+		// every page has issues as the file names do not match the slugs
+		filePath := filepath.Join(dir, fmt.Sprintf("%02d-page.md", i))
+		rawContent := fmt.Sprintf("+++\ntitle = \"Page %d\"\nweight = %d\nslug = \"page-%d\"\n+++\n\nSummary\n-------\n\n- foo\n", i, i*10, i)
+		require.NoError(t, os.WriteFile(filePath, []byte(rawContent), 0o644))
+
+		files = append(files, filePath)
+	}
+
+	tests := []struct {
+		name      string
+		maxErrors int
+		wantCount int
+	}{
+		{
+			name:      "all",
+			maxErrors: defaulMaxErrors,
+			wantCount: 20,
+		},
+		{
+			name:      "max errors",
+			maxErrors: 3,
+			wantCount: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			courses, count, problems := CrawlMarkdownFiles(files, tt.maxErrors, nil, false)
+
+			// verify
+			assert.Equal(t, tt.wantCount, count)
+			assert.Empty(t, problems)
+			require.Len(t, courses, 1)
+			require.Len(t, courses[0].Chapters, 1)
+
+			pages := courses[0].Chapters[0].Pages
+			require.Len(t, pages, tt.wantCount)
+			for i, page := range pages {
+				assert.Equal(t, files[i], page.FileName)
+			}
+		})
+	}
+}
+
+func TestCrawlMarkdownFiles_Problems(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "content", "go", "basics")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	files := []string{
+		filepath.Join(dir, "10-empty.md"),
+		filepath.Join(dir, "20-no-front-matter.md"),
+		filepath.Join(dir, "30-missing.md"),
+	}
+	require.NoError(t, os.WriteFile(files[0], nil, 0o644))
+	require.NoError(t, os.WriteFile(files[1], []byte("Summary\n-------\n\n- foo\n"), 0o644))
+
+	tests := []struct {
+		name      string
+		maxErrors int
+		wantRules []pkg.Rule
+	}{
+		{
+			name:      "all",
+			maxErrors: defaulMaxErrors,
+			wantRules: []pkg.Rule{pkg.RuleFileEmpty, pkg.RuleFrontMatterInvalid, pkg.RuleFileUnreadable},
+		},
+		{
+			name:      "max errors",
+			maxErrors: 2,
+			wantRules: []pkg.Rule{pkg.RuleFileEmpty, pkg.RuleFrontMatterInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			courses, count, problems := CrawlMarkdownFiles(files, tt.maxErrors, nil, false)
+
+			// verify
+			assert.Empty(t, courses)
+			assert.Equal(t, 0, count)
+
+			var rules []pkg.Rule
+			for _, problem := range problems {
+				rules = append(rules, problem.Rule)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func Test_checkInternalLinks(t *testing.T) {
+	courses := pkg.Courses{
+		{
+			Course: "go",
+			Chapters: pkg.Chapters{
+				{
+					Course:  "go",
+					Chapter: "basics",
+					Pages: pkg.Pages{
+						{
+							FileName: "content/go/basics/10-variables.md",
+							Content: pkg.Content{
+								Slug:    "variables",
+								Aliases: []string{"/go/old-variables"},
+								Anchors: map[string]struct{}{"summary": {}, "exercises": {}},
+							},
+						},
+						{
+							FileName: "content/go/basics/20-loops.md",
+							Course:   "go",
+							Chapter:  "basics",
+							Title:    "20-loops.md",
+							Content:  pkg.Content{Slug: "loop"},
+						},
+						{
+							FileName: "content/go/basics/30-functions.md",
+							Content: pkg.Content{
+								Slug:    "functions",
+								URL:     "/go/functions/",
+								Anchors: map[string]struct{}{"closures": {}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	links := sm.New[string, []string]()
+	links.Set("/go/basics/variables/#exercises", []string{"content/go/basics/20-loops.md:10:3"})
+	links.Set("/go/basics/variables#summary", []string{"content/go/basics/20-loops.md:11:3"})
+	links.Set("/go/basics/variables/#practice", []string{"content/go/basics/20-loops.md:12:3"})
+	links.Set("/go/basics/loops/#summary", []string{"content/go/basics/20-loops.md:13:3"})
+	links.Set("/go/old-variables/#summary", []string{"content/go/basics/20-loops.md:14:3"})
+	links.Set("/go/old-variables/#practice", []string{"content/go/basics/20-loops.md:15:3"})
+	links.Set("/go/functions/#closures", []string{"content/go/basics/20-loops.md:16:3"})
+
+	// execute
+	records := checkInternalLinks(io.Discard, ".", links, courses, false)
+
+	// verify
+	var got []string
+	for _, record := range records {
+		got = append(got, fmt.Sprintf("%s %s/%s %s", record.Location(), record.Course, record.Chapter, record.Message))
+	}
+	assert.ElementsMatch(t, []string{
+		"content/go/basics/20-loops.md:11:3 go/basics internal link is missing the trailing slash: /go/basics/variables#summary",
+		"content/go/basics/20-loops.md:12:3 go/basics internal link anchor not found: /go/basics/variables/#practice",
+		"content/go/basics/20-loops.md:13:3 go/basics internal link not found: /go/basics/loops/#summary",
+		"content/go/basics/20-loops.md:15:3 go/basics internal link anchor not found: /go/old-variables/#practice",
+	}, got)
+}
+
+func Test_main_ExitCodes(t *testing.T) {
+	// the test binary runs main itself when started by the test below, as main exits the process
+	if args := os.Getenv("CONTENT_CHECKER_ARGS"); args != "" {
+		os.Args = append([]string{"content-checker"}, strings.Fields(args)...)
+		main()
+		os.Exit(0)
+	}
+
+	newSite := func(indexLinks, pageLinks string) string {
+		root := t.TempDir()
+		files := map[string]string{
+			"content/go/basics/_index.md":       "+++\ntitle = \"Basics\"\nweight = 1\n+++\n\n" + indexLinks + "\n",
+			"content/go/basics/10-variables.md": "+++\ntitle = \"Variables\"\nweight = 10\nslug = \"variables\"\n+++\n\n" + pageLinks + "\n",
+			"static/logo.png":                   "png",
+		}
+		for fileName, content := range files {
+			filePath := filepath.Join(root, fileName)
+			require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+			require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+		}
+
+		return root
+	}
+
+	valid := newSite("[Variables](/go/basics/variables/)", "![Logo](/logo.png) and [Basics](/go/basics/)")
+	broken := newSite("", "![Logo](/Logo.png) and [Missing](/go/basics/missing/)")
+
+	// the page would be renamed to 20-loops.md, which is taken by a directory
+	renumber := newSite("", "")
+	require.NoError(t, os.WriteFile(filepath.Join(renumber, "content/go/basics/30-loops.md"), []byte("+++\ntitle = \"Loops\"\nweight = 30\nslug = \"loops\"\n+++\n"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(renumber, "content/go/basics/20-loops.md"), 0o755))
+
+	tests := []struct {
+		name       string
+		args       string
+		wantOutput string
+		wantCode   int
+	}{
+		{name: "check-links valid", args: "check-links " + valid, wantOutput: "All internal links found.", wantCode: 0},
+		{name: "check-links broken", args: "check-links " + broken, wantOutput: "Not found 1 internal links.", wantCode: exitContentErrors},
+		{name: "assets valid", args: "assets " + valid, wantOutput: "0 of them unreferenced", wantCode: 0},
+		{name: "assets broken", args: "assets --format json " + broken, wantOutput: "file link has the wrong case", wantCode: exitContentErrors},
+		{name: "orphans valid", args: "orphans " + valid, wantOutput: "No orphan pages found.", wantCode: 0},
+		// orphan pages are warnings
+		{name: "orphans broken", args: "orphans " + broken, wantOutput: "page is not linked from any other page", wantCode: 0},
+		{name: "renumber broken", args: "renumber " + renumber, wantOutput: "Renumbered 0 files.\nFailed to renumber 1 files.", wantCode: exitContentErrors},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^Test_main_ExitCodes$")
+			cmd.Env = append(os.Environ(), "CONTENT_CHECKER_ARGS="+tt.args)
+
+			// execute
+			output, err := cmd.CombinedOutput()
+
+			// verify
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ExitCode()
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCode, code, string(output))
+			assert.Contains(t, string(output), tt.wantOutput)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type urlClaim struct {
+	page Page
+	// link is the URL of the page, the one aliases redirect to
+	link  string
+	alias bool
+}
+
+func (c Courses) getURLClaims(root string) map[string][]urlClaim {
+	claims := make(map[string][]urlClaim)
+
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				link := chapter.GetInternalLink(root, page)
+				claims[link] = append(claims[link], urlClaim{page: page, link: link})
+
+				for _, alias := range chapter.GetAliases(root, page) {
+					claims[alias] = append(claims[alias], urlClaim{page: page, link: link, alias: true})
+				}
+			}
+		}
+	}
+
+	return claims
+}
+
+// GetAliasIssues reports URLs claimed by multiple pages, as Hugo silently serves only one of them.
+func (c Courses) GetAliasIssues(root string) []Record {
+	var records []Record
+
+	for url, claims := range c.getURLClaims(root) {
+		var fileNames []string
+		for _, claim := range claims {
+			if !slices.Contains(fileNames, claim.page.FileName) {
+				fileNames = append(fileNames, claim.page.FileName)
+			}
+		}
+
+		if len(fileNames) < 2 {
+			continue
+		}
+
+		slices.Sort(fileNames)
+
+		for _, claim := range claims {
+			issue := NewIssue(RuleAliasCollision, fmt.Sprintf("URL is claimed by multiple pages: %s, pages: %s", url, strings.Join(fileNames, ", ")))
+
+			switch {
+			case claim.alias:
+				issue = issue.At(claim.page.Content.Positions["aliases"])
+			case claim.page.Content.URL != "":
+				issue = issue.At(claim.page.Content.Positions["url"])
+			}
+
+			page := claim.page
+			records = append(records, NewRecord(page.FileName, page.Course, page.Chapter, page.Title, issue))
+		}
+	}
+
+	slices.SortFunc(records, func(a, b Record) int {
+		return cmp.Or(strings.Compare(a.FilePath, b.FilePath), cmp.Compare(a.Line, b.Line), strings.Compare(a.Message, b.Message))
+	})
+
+	return filterRecords(records)
+}
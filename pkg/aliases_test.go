@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAliasTestPage(fileName, slug, url string, aliases ...string) Page {
+	content := Content{
+		Slug:      slug,
+		URL:       url,
+		Aliases:   aliases,
+		Positions: map[string]Position{"url": {Line: 3, Column: 1}, "aliases": {Line: 4, Column: 1}},
+	}
+
+	return newTestPage(fileName, content)
+}
+
+func TestCourses_GetValidInternalLinks(t *testing.T) {
+	courses := Courses{
+		{
+			Course: "go",
+			Chapters: Chapters{
+				{
+					Course:  "go",
+					Chapter: "basics",
+					Pages: Pages{
+						newAliasTestPage("content/go/basics/_index.md", "fundamentals", "", "/go/basics/"),
+						newAliasTestPage("content/go/basics/10-variables.md", "variables", "", "vars", "/go/old-variables"),
+						newAliasTestPage("content/go/basics/20-loops.md", "loops", "go/loops"),
+					},
+				},
+				{
+					Course:  "go",
+					Chapter: "advanced",
+					Pages: Pages{
+						newAliasTestPage("content/go/advanced/_index.md", "", ""),
+						newAliasTestPage("content/go/advanced/10-generics.md", "generics", "", "../generics.html"),
+					},
+				},
+			},
+		},
+	}
+
+	// execute
+	got := courses.GetValidInternalLinks(".")
+
+	// verify
+	assert.Equal(t, map[string]struct{}{
+		"/go/fundamentals/":           {},
+		"/go/basics/":                 {},
+		"/go/fundamentals/variables/": {},
+		"/go/fundamentals/vars/":      {},
+		"/go/old-variables/":          {},
+		"/go/loops/":                  {},
+		"/go/advanced/":               {},
+		"/go/advanced/generics/":      {},
+		"/go/generics.html":           {},
+	}, got)
+}
+
+func TestCourses_GetAnchors(t *testing.T) {
+	variables := newAliasTestPage("content/go/basics/10-variables.md", "variables", "", "/go/old-variables", "/go/loops")
+	variables.Content.Anchors = map[string]struct{}{"loops": {}}
+	loops := newAliasTestPage("content/go/basics/20-loops.md", "loops", "go/loops")
+	loops.Content.Anchors = map[string]struct{}{"summary": {}}
+
+	courses := Courses{
+		{
+			Course: "go",
+			Chapters: Chapters{
+				{
+					Course:  "go",
+					Chapter: "basics",
+					Pages:   Pages{variables, loops},
+				},
+			},
+		},
+	}
+
+	// execute
+	got := courses.GetAnchors(".")
+
+	// verify
+	assert.Equal(t, map[string]map[string]struct{}{
+		"/go/basics/variables/": {"loops": {}},
+		"/go/old-variables/":    {"loops": {}},
+		"/go/loops/":            {"summary": {}},
+	}, got)
+}
+
+func TestCourses_GetAliasIssues(t *testing.T) {
+	courses := Courses{
+		{
+			Course: "go",
+			Chapters: Chapters{
+				{
+					Course:  "go",
+					Chapter: "basics",
+					Pages: Pages{
+						newAliasTestPage("content/go/basics/_index.md", "", ""),
+						newAliasTestPage("content/go/basics/10-variables.md", "variables", "", "/go/basics/loops/", "variables"),
+						newAliasTestPage("content/go/basics/20-loops.md", "loops", ""),
+						newAliasTestPage("content/go/basics/30-functions.md", "functions", "/go/basics/variables"),
+					},
+				},
+			},
+		},
+	}
+
+	// execute
+	got := courses.GetAliasIssues(".")
+
+	// verify
+	var messages []string
+	for _, record := range got {
+		messages = append(messages, record.Location()+" "+record.Message)
+	}
+	assert.Equal(t, []string{
+		"content/go/basics/10-variables.md URL is claimed by multiple pages: /go/basics/variables/, pages: content/go/basics/10-variables.md, content/go/basics/30-functions.md",
+		"content/go/basics/10-variables.md:4:1 URL is claimed by multiple pages: /go/basics/loops/, pages: content/go/basics/10-variables.md, content/go/basics/20-loops.md",
+		"content/go/basics/10-variables.md:4:1 URL is claimed by multiple pages: /go/basics/variables/, pages: content/go/basics/10-variables.md, content/go/basics/30-functions.md",
+		"content/go/basics/20-loops.md URL is claimed by multiple pages: /go/basics/loops/, pages: content/go/basics/10-variables.md, content/go/basics/20-loops.md",
+		"content/go/basics/30-functions.md:3:1 URL is claimed by multiple pages: /go/basics/variables/, pages: content/go/basics/10-variables.md, content/go/basics/30-functions.md",
+	}, messages)
+}
@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var regexHeading = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+var regexHeadingID = regexp.MustCompile(`\s*\{#([^}\s]+)\}$`)
+
+// anchorize follows the default settings of Hugo.
+func anchorize(title string) string {
+	var result strings.Builder
+
+	for _, r := range strings.TrimSpace(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			result.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r):
+			result.WriteRune('-')
+		}
+	}
+
+	return result.String()
+}
+
+// headingAnchor respects custom heading ids like `## Exercises {#practice}`.
+func headingAnchor(title string) string {
+	if matches := regexHeadingID.FindStringSubmatch(title); len(matches) == 2 {
+		return matches[1]
+	}
+
+	return anchorize(title)
+}
+
+// Anchors suffixes repeated headings with a number, just like Hugo.
+func (s Sections) Anchors() map[string]struct{} {
+	anchors := make(map[string]struct{})
+
+	add := func(title string) {
+		anchor := headingAnchor(title)
+		if anchor == "" {
+			return
+		}
+
+		unique := anchor
+		for i := 1; ; i++ {
+			if _, ok := anchors[unique]; !ok {
+				break
+			}
+
+			unique = anchor + "-" + strconv.Itoa(i)
+		}
+
+		anchors[unique] = struct{}{}
+	}
+
+	for i, section := range s {
+		// the root section, holding the content before the first section, has no heading
+		if i > 0 || section.Title != "root" {
+			add(section.Title)
+		}
+
+		var code codeBlocks
+		for _, row := range strings.Split(section.Content, EOL) {
+			if code.isCode(row) {
+				continue
+			}
+
+			if matches := regexHeading.FindStringSubmatch(row); len(matches) == 2 {
+				add(matches[1])
+			}
+		}
+	}
+
+	return anchors
+}
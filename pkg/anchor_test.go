@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_anchorize(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "simple", title: "Main Video", want: "main-video"},
+		{name: "punctuation", title: "What's new in Go 1.22?", want: "whats-new-in-go-122"},
+		{name: "dashes", title: "Colossus - Computerphile", want: "colossus---computerphile"},
+		{name: "formatting", title: "The `for` loop", want: "the-for-loop"},
+		{name: "unicode", title: "Árvíztűrő tükörfúrógép", want: "árvíztűrő-tükörfúrógép"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := anchorize(tt.title)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSections_Anchors(t *testing.T) {
+	body := `Intro
+
+## Summary
+
+- foo
+
+### Example
+
+## Exercises {#practice}
+
+### Example
+
+` + "```bash\n# not a heading\n```\n\n~~~bash\n# not a heading either\n~~~" + `
+
+### Example
+`
+
+	// execute
+	got := extractSections(body, 1).Anchors()
+
+	// verify
+	assert.Equal(t, map[string]struct{}{
+		"summary":   {},
+		"example":   {},
+		"practice":  {},
+		"example-1": {},
+		"example-2": {},
+	}, got)
+}
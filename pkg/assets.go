@@ -0,0 +1,155 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var assetDirs = []string{"static", "content"}
+
+type Asset struct {
+	FilePath string
+	URL      string
+	Size     int64
+}
+
+func FindAssets(root string) ([]Asset, error) {
+	var assets []Asset
+
+	for _, dir := range assetDirs {
+		base := filepath.Join(root, dir)
+
+		err := filepath.WalkDir(base, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if strings.HasPrefix(entry.Name(), ".") && filePath != base {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if entry.IsDir() || filepath.Ext(filePath) == ".md" {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(base, filePath)
+			if err != nil {
+				return err
+			}
+
+			assets = append(assets, Asset{FilePath: filePath, URL: "/" + filepath.ToSlash(rel), Size: info.Size()})
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("assets could not be listed in %s, err: %w", base, err)
+		}
+	}
+
+	return assets, nil
+}
+
+// getFileLinkURL resolves relative links against the URL of the page, just like check-links does.
+func getFileLinkURL(pageURL, link string) string {
+	if strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") {
+		return ""
+	}
+
+	link, _ = SplitFragment(link)
+	if index := strings.Index(link, "?"); index >= 0 {
+		link = link[:index]
+	}
+
+	if path.Ext(link) == "" {
+		return ""
+	}
+
+	return resolveInternalLink(pageURL, link)
+}
+
+// GetAssetIssues reports references only matching an asset when ignoring the case, as they break on case-sensitive file
+// systems.
+func (c Courses) GetAssetIssues(root string, assets []Asset) []Record {
+	byURL := make(map[string]Asset, len(assets))
+	byLowerURL := make(map[string]Asset, len(assets))
+	for _, asset := range assets {
+		byURL[asset.URL] = asset
+		byLowerURL[strings.ToLower(asset.URL)] = asset
+	}
+
+	referenced := make(map[string]struct{})
+
+	var records []Record
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				for _, link := range page.Content.Links {
+					if link.Kind == LinkKindRef {
+						continue
+					}
+
+					url := getFileLinkURL(chapter.GetInternalLink(root, page), link.URL)
+					if url == "" {
+						continue
+					}
+
+					if _, ok := byURL[url]; ok {
+						referenced[url] = struct{}{}
+
+						continue
+					}
+
+					asset, ok := byLowerURL[strings.ToLower(url)]
+					if !ok {
+						continue
+					}
+
+					referenced[asset.URL] = struct{}{}
+
+					issue := NewIssue(RuleFileLinkCase, fmt.Sprintf("file link has the wrong case: %s, file: %s", link.URL, asset.URL))
+					records = append(records, NewRecord(page.FileName, page.Course, page.Chapter, page.Title, issue.At(link.Position)))
+				}
+			}
+		}
+	}
+
+	for _, asset := range assets {
+		if _, ok := referenced[asset.URL]; ok {
+			continue
+		}
+
+		issue := NewIssue(RuleAssetUnreferenced, fmt.Sprintf("asset is not referenced by any page: %s (%s)", asset.URL, formatSize(asset.Size)))
+		records = append(records, NewRecord(asset.FilePath, "", "", filepath.Base(asset.FilePath), issue))
+	}
+
+	return filterRecords(records)
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourses_GetAssetIssues(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]int{
+		"static/images/logo.png":              10,
+		"static/images/unused.png":            2048,
+		"static/images/.DS_Store":             10,
+		"static/downloads/Cheatsheet.pdf":     10,
+		"content/go/basics/loops/diagram.png": 10,
+		"content/go/basics/20-loops.md":       10,
+	}
+	for name, size := range files {
+		filePath := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Repeat("x", size)), 0o644))
+	}
+
+	assets, err := FindAssets(root)
+	require.NoError(t, err)
+
+	courses := Courses{
+		{
+			Course: "go",
+			Chapters: Chapters{
+				{
+					Course:  "go",
+					Chapter: "basics",
+					Pages: Pages{
+						{
+							FileName: filepath.Join(root, "content/go/basics/20-loops.md"),
+							Course:   "go",
+							Chapter:  "basics",
+							Title:    "20-loops.md",
+							Content: Content{
+								Slug: "loops",
+								Links: []Link{
+									{Kind: LinkKindImage, URL: "/images/logo.png", Position: Position{Line: 10, Column: 3}},
+									{Kind: LinkKindAnchor, URL: "/downloads/cheatsheet.pdf#page=2", Position: Position{Line: 12, Column: 5}},
+									{Kind: LinkKindImage, URL: "diagram.png", Position: Position{Line: 14, Column: 1}},
+									{Kind: LinkKindAnchor, URL: "/go/basics/variables/", Position: Position{Line: 16, Column: 1}},
+									{Kind: LinkKindImage, URL: "https://example.com/unused.png", Position: Position{Line: 18, Column: 1}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// execute
+	got := courses.GetAssetIssues(root, assets)
+
+	// verify
+	var messages []string
+	for _, record := range got {
+		location := strings.TrimPrefix(record.Location(), root+string(filepath.Separator))
+		messages = append(messages, location+" "+record.Message)
+	}
+	assert.Equal(t, []string{
+		"content/go/basics/20-loops.md:12:5 file link has the wrong case: /downloads/cheatsheet.pdf#page=2, file: /downloads/Cheatsheet.pdf",
+		"static/images/unused.png asset is not referenced by any page: /images/unused.png (2.0 KB)",
+	}, messages)
+}
+
+func Test_formatSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		want string
+	}{
+		{name: "bytes", size: 512, want: "512 B"},
+		{name: "kilobytes", size: 1536, want: "1.5 KB"},
+		{name: "megabytes", size: 5 * 1024 * 1024, want: "5.0 MB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := formatSize(tt.size)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package pkg
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const ConfigFileName = ".content-checker.toml"
+
+type Config struct {
+	MaxNonFullCourseLength    int             `toml:"maxNonFullCourseLength"`
+	MaxExtraLength            int             `toml:"maxExtraLength"`
+	MinDeepDiveLength         int             `toml:"minDeepDiveLength"`
+	SkipDomains               []string        `toml:"skipDomains"`
+	AdditionalAudiences       []string        `toml:"additionalAudiences"`
+	AdditionalBadges          []string        `toml:"additionalBadges"`
+	SectionOrder              []string        `toml:"sectionOrder"`
+	FrontMatterFormat         string          `toml:"frontMatterFormat"`
+	AdditionalFrontMatterKeys []string        `toml:"additionalFrontMatterKeys"`
+	LinkCacheTTL              string          `toml:"linkCacheTTL"`
+	UserAgent                 string          `toml:"userAgent"`
+	RequestTimeout            string          `toml:"requestTimeout"`
+	DomainWorkers             int             `toml:"domainWorkers"`
+	MaxDomains                int             `toml:"maxDomains"`
+	OrphanEntryPoints         []string        `toml:"orphanEntryPoints"`
+	Rules                     map[string]bool `toml:"rules"`
+}
+
+var defaultSkipDomains = []string{
+	"codeforces.com",
+	"developer.android.com",
+	"leetcode.com",
+	"linux.die.net",
+	"marketplace.visualstudio.com",
+	"udemy.com",
+	"youtube.com",
+	"www.amazon.com",
+	"www.canva.com",
+	"www.cloudflare.com",
+	"www.java.com",
+	"www.linux.org",
+	"www.make.com",
+	"www.mercurial-scm.org",
+	"www.skillshare.com",
+	"www.softwaretestinghelp.com",
+	"www.whatsapp.com",
+	"www.youtube.com",
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxNonFullCourseLength: 119,
+		MaxExtraLength:         60,
+		MinDeepDiveLength:      30,
+		SkipDomains:            append([]string{}, defaultSkipDomains...),
+		SectionOrder:           append([]string{}, defaultBodySectionOrder...),
+		LinkCacheTTL:           "24h",
+		UserAgent:              defaultUserAgent,
+		RequestTimeout:         defaultRequestTimeout.String(),
+		DomainWorkers:          defaultDomainWorkers,
+		MaxDomains:             10,
+		Rules:                  map[string]bool{},
+	}
+}
+
+// GetLinkCacheTTL disables the cache for invalid values, which are reported by Validate.
+func (c Config) GetLinkCacheTTL() time.Duration {
+	ttl, _ := time.ParseDuration(c.LinkCacheTTL)
+
+	return ttl
+}
+
+// LoadConfig returns the default configuration if the file is missing.
+func LoadConfig(root string) (Config, []string, error) {
+	config := DefaultConfig()
+
+	meta, err := toml.DecodeFile(filepath.Join(root, ConfigFileName), &config)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultConfig(), nil, nil
+	}
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("config could not be parsed, err: %w", err)
+	}
+
+	var unknownKeys []string
+	for _, key := range meta.Undecoded() {
+		unknownKeys = append(unknownKeys, key.String())
+	}
+
+	return config, unknownKeys, nil
+}
+
+func (c Config) Validate() []string {
+	var problems []string
+
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := LookupRule(name); !ok {
+			problems = append(problems, "unknown rule: "+name)
+		}
+	}
+
+	if c.MaxExtraLength > c.MaxNonFullCourseLength {
+		problems = append(problems, "maxExtraLength is larger than maxNonFullCourseLength")
+	}
+
+	if c.MinDeepDiveLength > c.MaxNonFullCourseLength {
+		problems = append(problems, "minDeepDiveLength is larger than maxNonFullCourseLength")
+	}
+
+	if _, err := ParseFrontMatterFormat(c.FrontMatterFormat); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if _, err := time.ParseDuration(c.LinkCacheTTL); err != nil {
+		problems = append(problems, "invalid linkCacheTTL: "+c.LinkCacheTTL)
+	}
+
+	if timeout, err := time.ParseDuration(c.RequestTimeout); err != nil || timeout <= 0 {
+		problems = append(problems, "invalid requestTimeout: "+c.RequestTimeout)
+	}
+
+	if c.DomainWorkers < 1 {
+		problems = append(problems, "domainWorkers must be at least 1")
+	}
+
+	if c.MaxDomains < 1 {
+		problems = append(problems, "maxDomains must be at least 1")
+	}
+
+	seen := make(map[string]struct{}, len(c.SectionOrder))
+	for _, title := range c.SectionOrder {
+		if _, ok := seen[title]; ok {
+			problems = append(problems, "duplicate section in sectionOrder: "+title)
+		}
+
+		seen[title] = struct{}{}
+	}
+
+	return problems
+}
+
+var (
+	additionalBadges = map[Badge]struct{}{}
+	disabledRules    = map[Rule]struct{}{}
+	// any format is allowed if empty
+	requiredFrontMatterFormat FrontMatterFormat
+	additionalFrontMatterKeys = map[string]struct{}{}
+)
+
+func ApplyConfig(config Config) {
+	maxNonFullCourseLength = config.MaxNonFullCourseLength
+	maxExtraLength = config.MaxExtraLength
+	minDeepDiveLength = config.MinDeepDiveLength
+
+	audiences := make(map[Audience]struct{}, len(builtInAudiences)+len(config.AdditionalAudiences))
+	for audience := range builtInAudiences {
+		audiences[audience] = struct{}{}
+	}
+	for _, audience := range config.AdditionalAudiences {
+		audiences[Audience(audience)] = struct{}{}
+	}
+	validAudiences = audiences
+
+	badges := make(map[Badge]struct{}, len(config.AdditionalBadges))
+	for _, badge := range config.AdditionalBadges {
+		badges[Badge(badge)] = struct{}{}
+	}
+	additionalBadges = badges
+
+	defaultBodySectionMap = sectionOrderToMap(config.SectionOrder)
+
+	// unknown formats are reported by Validate, they are not enforced
+	requiredFrontMatterFormat, _ = ParseFrontMatterFormat(config.FrontMatterFormat)
+
+	keys := make(map[string]struct{}, len(config.AdditionalFrontMatterKeys))
+	for _, key := range config.AdditionalFrontMatterKeys {
+		keys[strings.ToLower(key)] = struct{}{}
+	}
+	additionalFrontMatterKeys = keys
+
+	// invalid values are reported by Validate, the defaults are used instead
+	httpUserAgent = cmp.Or(config.UserAgent, defaultUserAgent)
+	requestTimeout = defaultRequestTimeout
+	if timeout, err := time.ParseDuration(config.RequestTimeout); err == nil && timeout > 0 {
+		requestTimeout = timeout
+	}
+	domainWorkers = max(config.DomainWorkers, 1)
+
+	rules := make(map[Rule]struct{})
+	for name, enabled := range config.Rules {
+		if info, ok := LookupRule(name); ok && !enabled {
+			rules[info.Rule] = struct{}{}
+		}
+	}
+	disabledRules = rules
+}
+
+func IsRuleEnabled(rule Rule) bool {
+	_, disabled := disabledRules[rule]
+
+	return !disabled
+}
+
+func filterIssues(issues []Issue) []Issue {
+	if len(disabledRules) == 0 {
+		return issues
+	}
+
+	var result []Issue
+	for _, issue := range issues {
+		if IsRuleEnabled(issue.Rule) {
+			result = append(result, issue)
+		}
+	}
+
+	return result
+}
+
+func filterRecords(records []Record) []Record {
+	if len(disabledRules) == 0 {
+		return records
+	}
+
+	var result []Record
+	for _, record := range records {
+		if IsRuleEnabled(record.Rule) {
+			result = append(result, record)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("missing file results in default config", func(t *testing.T) {
+		// execute
+		config, unknownKeys, err := LoadConfig(t.TempDir())
+		require.NoError(t, err)
+
+		// verify
+		assert.Equal(t, DefaultConfig(), config)
+		assert.Empty(t, unknownKeys)
+	})
+
+	t.Run("values are overridden and unknown keys returned", func(t *testing.T) {
+		root := t.TempDir()
+		rawConfig := `maxExtraLength = 45
+additionalAudiences = ["students"]
+audiance = "all"
+linkCacheTTL = "a week"
+
+[rules]
+badge-length = false
+foo = true
+`
+		require.NoError(t, os.WriteFile(filepath.Join(root, ConfigFileName), []byte(rawConfig), 0o644))
+
+		// execute
+		config, unknownKeys, err := LoadConfig(root)
+		require.NoError(t, err)
+
+		// verify
+		assert.Equal(t, 45, config.MaxExtraLength)
+		assert.Equal(t, 119, config.MaxNonFullCourseLength)
+		assert.Equal(t, []string{"students"}, config.AdditionalAudiences)
+		assert.Equal(t, []string{"audiance"}, unknownKeys)
+		assert.Equal(t, []string{"unknown rule: foo", "invalid linkCacheTTL: a week"}, config.Validate())
+	})
+
+	t.Run("broken file is an error", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, ConfigFileName), []byte("maxExtraLength = "), 0o644))
+
+		// execute
+		_, _, err := LoadConfig(root)
+
+		// verify
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyConfig(t *testing.T) {
+	defer ApplyConfig(DefaultConfig())
+
+	config := DefaultConfig()
+	config.MaxNonFullCourseLength = 200
+	config.AdditionalAudiences = []string{"students"}
+	config.AdditionalBadges = []string{"new"}
+	config.AdditionalFrontMatterKeys = []string{"videoID"}
+	config.Rules = map[string]bool{"CC109": false}
+
+	// execute
+	ApplyConfig(config)
+
+	// verify
+	content := Content{
+		Title:      "Foo",
+		Slug:       "foo",
+		Weight:     "10",
+		State:      Stub,
+		Audience:   "students",
+		Importance: Essential,
+		Tags:       []string{"Foo"},
+		Body:       &IndexBody{},
+	}
+	issues := content.GetIssues("foo/foo/_index.md", "foo", "foo", "_index.md")
+	assert.Equal(t, []Issue{NewIssue(RuleOutsideImportance, "outside importance is invalid")}, issues)
+
+	video := extractVideo("{{< time 150 >}} {{< badge-extra >}} {{< badge-new >}}\n\n{{< youtube abc >}}", false)
+	assert.Equal(t, []Issue{NewIssue(RuleBadgeLength, "badges should have deep-dive, but do not. badges: extra").At(Position{Line: 1, Column: 1})}, video.Issues)
+
+	assert.Empty(t, getUnknownKeyIssues(map[string]any{"videoid": "abc"}, nil))
+}
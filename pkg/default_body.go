@@ -16,34 +16,51 @@ type DefaultBody struct {
 	SlugForced         bool
 	Project            bool
 	SectionTitles      []string
+	SectionPositions   map[string]Position
 }
 
-var defaultBodySectionMap = map[string]int{
-	sectionRoot:            0,
-	sectionMainVideo:       1,
-	sectionSummary:         2,
-	sectionTopics:          3,
-	sectionCode:            4,
-	sectionRelatedLessons:  5,
-	sectionRelatedVideos:   6,
-	sectionRelatedArticles: 7,
-	sectionRelatedLinks:    8,
-	sectionExercises:       9,
-	sectionNotes:           10,
+var defaultBodySectionOrder = []string{
+	sectionRoot,
+	sectionMainVideo,
+	sectionSummary,
+	sectionTopics,
+	sectionCode,
+	sectionRelatedLessons,
+	sectionRelatedVideos,
+	sectionRelatedArticles,
+	sectionRelatedLinks,
+	sectionExercises,
+	sectionNotes,
 }
 
-func (db DefaultBody) GetIssues(state State) []string {
+var defaultBodySectionMap = sectionOrderToMap(defaultBodySectionOrder)
+
+// sectionOrderToMap converts a list of section titles into a map of the titles and their index. The root section is
+// always allowed first.
+func sectionOrderToMap(order []string) map[string]int {
+	sectionMap := map[string]int{sectionRoot: 0}
+
+	for _, title := range order {
+		if _, ok := sectionMap[title]; !ok {
+			sectionMap[title] = len(sectionMap)
+		}
+	}
+
+	return sectionMap
+}
+
+func (db DefaultBody) GetIssues(state State) []Issue {
 	issues := db.Main.GetIssues()
 	issues = append(issues, db.RelatedVideos.GetIssues()...)
 
 	switch db.Main.Status {
 	case VideoReallyMissing:
 		if db.UsefulWithoutVideo {
-			issues = append(issues, "main video is NOT REALLY missing (Remove the useful-without-video tag?")
+			issues = append(issues, NewIssue(RuleMainVideoNotMissing, "main video is NOT REALLY missing (Remove the useful-without-video tag?").At(db.SectionPositions[sectionMainVideo]))
 		}
 	case VideoMissing:
 		if !db.RelatedVideos.Has(Alternative, DeepDive, FullCourse) && !db.UsefulWithoutVideo {
-			issues = append(issues, "main video is REALLY missing (Add a useful-without-video tag?")
+			issues = append(issues, NewIssue(RuleMainVideoMissing, "main video is REALLY missing (Add a useful-without-video tag?").At(db.SectionPositions[sectionMainVideo]))
 		}
 	}
 
@@ -54,20 +71,20 @@ func (db DefaultBody) GetIssues(state State) []string {
 			msg = err.Error()
 		}
 
-		issues = append(issues, fmt.Sprintf("state mismatch. got: %s, want: %s, reason: %s", state, calculatedStates, msg))
+		issues = append(issues, NewIssue(RuleStateMismatch, fmt.Sprintf("state mismatch. got: %s, want: %s, reason: %s", state, calculatedStates, msg)))
 	}
 
 	if item, ok := isOrderedCorrectly(defaultBodySectionMap, db.SectionTitles); !ok {
-		issues = append(issues, "sections are not in the correct order, first out of order: "+item)
+		issues = append(issues, NewIssue(RuleSectionOrder, "sections are not in the correct order, first out of order: "+item).At(db.SectionPositions[item]))
 	}
 
 	if !db.Project {
 		if !db.HasSummary {
-			issues = append(issues, "summary section is missing")
+			issues = append(issues, NewIssue(RuleSummaryMissing, "summary section is missing"))
 		}
 
 		if !db.HasTopics {
-			issues = append(issues, "topics section is missing")
+			issues = append(issues, NewIssue(RuleTopicsMissing, "topics section is missing"))
 		}
 	}
 
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldContent string
+		newContent string
+		want       string
+	}{
+		{
+			name:       "equal",
+			oldContent: "a\nb\n",
+			newContent: "a\nb\n",
+			want:       "",
+		},
+		{
+			name:       "changed line",
+			oldContent: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			newContent: "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want:       "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:       "added line",
+			oldContent: "1\n2\n",
+			newContent: "1\n2\n3\n",
+			want:       "--- old\n+++ new\n@@ -1,2 +1,3 @@\n 1\n 2\n+3\n",
+		},
+		{
+			name:       "separate hunks",
+			oldContent: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			newContent: "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want:       "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := UnifiedDiff("old", "new", tt.oldContent, tt.newContent)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
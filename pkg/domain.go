@@ -2,8 +2,11 @@ package pkg
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -78,7 +81,7 @@ const (
 	DataEngineers     Audience = "data engineers"
 )
 
-var validAudiences = map[Audience]struct{}{
+var builtInAudiences = map[Audience]struct{}{
 	All:               {},
 	AllProfessionals:  {},
 	LinuxUsers:        {},
@@ -93,6 +96,8 @@ var validAudiences = map[Audience]struct{}{
 	DataEngineers:     {},
 }
 
+var validAudiences = builtInAudiences
+
 type Importance string
 
 const (
@@ -138,21 +143,21 @@ func (m Main) Has(badges ...Badge) bool {
 	return m.Videos.Has(badges...)
 }
 
-func (m Main) GetIssues() []string {
+func (m Main) GetIssues() []Issue {
 	return m.Videos.GetIssues()
 }
 
 type Video struct {
 	Badges  Badges
-	Issues  []string
+	Issues  []Issue
 	Minutes int
 	Valid   bool
 }
 
 type Videos []Video
 
-func (v Videos) GetIssues() []string {
-	var issues []string
+func (v Videos) GetIssues() []Issue {
+	var issues []Issue
 
 	for _, item := range v {
 		issues = append(issues, item.Issues...)
@@ -161,6 +166,34 @@ func (v Videos) GetIssues() []string {
 	return issues
 }
 
+func (v Video) offset(base Position) Video {
+	if len(v.Issues) == 0 {
+		return v
+	}
+
+	issues := make([]Issue, 0, len(v.Issues))
+	for _, issue := range v.Issues {
+		issues = append(issues, issue.At(issue.Position.Offset(base)))
+	}
+
+	v.Issues = issues
+
+	return v
+}
+
+func (v Videos) offset(base Position) Videos {
+	if v == nil {
+		return nil
+	}
+
+	videos := make(Videos, 0, len(v))
+	for _, item := range v {
+		videos = append(videos, item.offset(base))
+	}
+
+	return videos
+}
+
 func (v Videos) Has(badges ...Badge) bool {
 	for _, item := range v {
 		for _, badge := range badges {
@@ -206,12 +239,13 @@ func isOrderedCorrectly(goldenMap map[string]int, givenSlice []string) (string,
 }
 
 type Body interface {
-	GetIssues(state State) []string
+	GetIssues(state State) []Issue
 	CalculateState() (State, error)
 	IsSlugForced() bool
 }
 
 type Content struct {
+	FrontMatterFormat FrontMatterFormat
 	Title             string
 	State             State
 	Body              Body
@@ -222,7 +256,15 @@ type Content struct {
 	OutsideImportance Importance
 	Tags              []string
 	EmptySections     []string
-	Links             map[string]string
+	Links             []Link
+	URL               string
+	Aliases           []string
+	Anchors           map[string]struct{}
+	Positions         map[string]Position
+	SectionPositions  map[string]Position
+	Suppressions      []Suppression
+	FrontMatterIssues []Issue
+	LinkIssues        []Issue
 }
 
 var regexDashes = regexp.MustCompile(`-+-`)
@@ -255,63 +297,83 @@ func slugify(title string) string {
 	return strings.Trim(title, "-")
 }
 
-func (c Content) GetIssues(filePath, course, chapter, page string) []string {
-	issues := c.Body.GetIssues(c.State)
+func (c Content) GetIssues(filePath, course, chapter, page string) []Issue {
+	// without the front matter values every other check would only report noise
+	for _, issue := range c.FrontMatterIssues {
+		if issue.Rule == RuleFrontMatterSyntax {
+			return filterIssues(c.FrontMatterIssues)
+		}
+	}
+
+	issues := append(c.Body.GetIssues(c.State), c.FrontMatterIssues...)
+	issues = append(issues, c.LinkIssues...)
+
+	// the body does not know about the front matter, so state mismatches are pointed to the state key here
+	for i, issue := range issues {
+		if issue.Rule == RuleStateMismatch && issue.Position.IsZero() {
+			issues[i] = issue.At(c.Positions["state"])
+		}
+	}
+
+	if requiredFrontMatterFormat != "" && c.FrontMatterFormat != requiredFrontMatterFormat {
+		issues = append(issues, NewIssue(RuleFrontMatterFormat, fmt.Sprintf("front matter format is %s, expected: %s", c.FrontMatterFormat, requiredFrontMatterFormat)).At(Position{Line: 1, Column: 1}))
+	}
 
 	slug := slugify(c.Title)
 
 	_, isIndex := c.Body.(*IndexBody)
 	if !isIndex {
 		if !strings.HasPrefix(page, c.Weight) {
-			issues = append(issues, fmt.Sprintf("file name is not prefixed with the weight of the page, file name: %s, weight: %s", page, c.Weight))
+			issues = append(issues, NewIssue(RuleFileNameWeight, fmt.Sprintf("file name is not prefixed with the weight of the page, file name: %s, weight: %s", page, c.Weight)))
 		}
 
 		if fmt.Sprintf("%s-%s.md", c.Weight, c.Slug) != page {
-			issues = append(issues, fmt.Sprintf("file name does not match the dash joined weight and slug, file name: %s, weight: %s", page, c.Weight))
+			issues = append(issues, NewIssue(RuleFileNameSlug, fmt.Sprintf("file name does not match the dash joined weight and slug, file name: %s, weight: %s", page, c.Weight)))
 		}
 
 		if !c.Body.IsSlugForced() && c.Slug != slug {
-			issues = append(issues, fmt.Sprintf("slug does not match the lowercase title with dashes (`%s`, `%s`)", c.Slug, slug))
+			issues = append(issues, NewIssue(RuleSlugMismatch, fmt.Sprintf("slug does not match the lowercase title with dashes (`%s`, `%s`)", c.Slug, slug)).At(c.Positions["slug"]))
 		}
 	} else {
 		if chapter != slug {
-			issues = append(issues, fmt.Sprintf("chapter does not match the slug, file name: %s, chapter: %s, slug: %s", page, chapter, slug))
+			issues = append(issues, NewIssue(RuleChapterSlug, fmt.Sprintf("chapter does not match the slug, file name: %s, chapter: %s, slug: %s", page, chapter, slug)).At(c.Positions["title"]))
 		}
 	}
 
 	if c.State == Complete && len(c.EmptySections) > 0 {
-		issues = append(issues, fmt.Sprintf("empty sections: %s", strings.Join(c.EmptySections, ", ")))
+		issues = append(issues, NewIssue(RuleEmptySections, fmt.Sprintf("empty sections: %s", strings.Join(c.EmptySections, ", "))).At(c.SectionPositions[c.EmptySections[0]]))
 	}
 
 	if _, exists := validAudiences[c.Audience]; !exists {
-		issues = append(issues, "invalid audience: "+string(c.Audience))
+		issues = append(issues, NewIssue(RuleInvalidAudience, "invalid audience: "+string(c.Audience)).At(c.Positions["audience"]))
 	}
 
 	if c.Importance.Level() < c.OutsideImportance.Level() {
-		issues = append(issues, "importance is lower than outside importance")
+		issues = append(issues, NewIssue(RuleImportanceOrder, "importance is lower than outside importance").At(c.Positions["audienceImportance"]))
 	}
 
 	if c.OutsideImportance == "" && c.Audience != All {
-		issues = append(issues, "outside importance is invalid")
+		issues = append(issues, NewIssue(RuleOutsideImportance, "outside importance is invalid").At(c.Positions["audience"]))
 	}
 
 	if c.Audience == All && c.OutsideImportance != "" {
-		issues = append(issues, "audience is 'all', outside importance must be empty")
+		issues = append(issues, NewIssue(RuleOutsideImportance, "audience is 'all', outside importance must be empty").At(c.Positions["outsideImportance"]))
 	}
 
+	tagsPosition := c.Positions["tags"]
 	for _, tag := range c.Tags {
 		if tag == "unsorted" {
-			issues = append(issues, "tag is 'unsorted'")
+			issues = append(issues, NewIssue(RuleTagUnsorted, "tag is 'unsorted'").At(tagsPosition))
 		}
 		if strings.ToLower(tag) != tag {
-			issues = append(issues, "tag is not lowercase: "+tag)
+			issues = append(issues, NewIssue(RuleTagCase, "tag is not lowercase: "+tag).At(tagsPosition))
 		}
 		if strings.Replace(tag, " ", "", 1) != tag {
-			issues = append(issues, "tag contains spaces: "+tag)
+			issues = append(issues, NewIssue(RuleTagSpaces, "tag contains spaces: "+tag).At(tagsPosition))
 		}
 	}
 
-	return issues
+	return filterIssues(applySuppressions(issues, c.Suppressions))
 }
 
 type Page struct {
@@ -322,17 +384,17 @@ type Page struct {
 	FileName string
 }
 
-func (p Page) GetIssues() []string {
+func (p Page) GetIssues() []Issue {
 	issues := p.Content.GetIssues(p.FileName, p.Course, p.Chapter, p.Title)
 
 	return issues
 }
 
-func (p Page) GetErrors() []string {
-	var errors []string
+func (p Page) GetErrors() []Record {
+	var errors []Record
 
 	for _, issue := range p.GetIssues() {
-		errors = append(errors, fmt.Sprintf("%s - %s", p.FileName, issue))
+		errors = append(errors, NewRecord(p.FileName, p.Course, p.Chapter, p.Title, issue))
 	}
 
 	return errors
@@ -377,15 +439,22 @@ func (p Page) String() string {
 	return result
 }
 
-func (p Page) GetInternalLink() string {
-	filePath := p.FileName
-
-	if strings.HasPrefix(filePath, "content/") {
-		filePath = strings.TrimPrefix(filePath, "content")
+// GetInternalLink returns the url key of the front matter if set, otherwise the slug replaces the file name of pages and
+// the directory name of chapter indexes.
+func (p Page) GetInternalLink(root string) string {
+	if p.Content.URL != "" {
+		return cleanPageURL(p.Content.URL)
 	}
 
+	filePath := "/" + contentPath(root, p.FileName)
+
 	if strings.HasSuffix(filePath, "_index.md") {
-		return strings.TrimSuffix(filePath, "_index.md")
+		dir := strings.TrimSuffix(filePath, "_index.md")
+		if p.Content.Slug == "" || dir == "/" {
+			return dir
+		}
+
+		return path.Join(path.Dir(strings.TrimSuffix(dir, "/")), p.Content.Slug) + "/"
 	}
 
 	filename := filepath.Base(filePath)
@@ -393,6 +462,16 @@ func (p Page) GetInternalLink() string {
 	return strings.Replace(filePath, filename, p.Content.Slug, 1) + "/"
 }
 
+func cleanPageURL(link string) string {
+	link = path.Join("/", link)
+
+	if link != "/" && path.Ext(link) == "" {
+		link += "/"
+	}
+
+	return link
+}
+
 type Pages []Page
 
 func (p Pages) Add(filePath, courseFN, chapterFN, pageFN string, content Content) Pages {
@@ -448,8 +527,8 @@ func (c *Chapter) GetWeight() int {
 	return 0
 }
 
-func (c *Chapter) GetErrors() []string {
-	var errors []string
+func (c *Chapter) GetErrors() []Record {
+	var errors []Record
 
 	for _, page := range c.Pages {
 		errors = append(errors, page.GetErrors()...)
@@ -458,10 +537,10 @@ func (c *Chapter) GetErrors() []string {
 	return errors
 }
 
-func (c *Chapter) GetPageOrderIssues() []string {
+func (c *Chapter) GetPageOrderIssues() []Record {
 	seen := make(map[int][]string, len(c.Pages))
 	largestWeight := 0
-	var issues []string
+	var issues []Record
 
 	for _, page := range c.Pages {
 		if page.Title == "_index.md" {
@@ -476,12 +555,12 @@ func (c *Chapter) GetPageOrderIssues() []string {
 		}
 
 		if weight%10 != 0 {
-			issues = append(issues, fmt.Sprintf("weird weight: %d (%s)", weight, c.Chapter))
+			issues = append(issues, c.newRecord(page.FileName, page.Title, NewIssue(RulePageWeight, fmt.Sprintf("weird weight: %d (%s)", weight, c.Chapter))))
 		}
 	}
 
 	if largestWeight < 1 {
-		issues = append(issues, fmt.Sprintf("no pages found in chapter (%s)", c.Chapter))
+		issues = append(issues, c.newRecord(c.GetIndexFileName(), "_index.md", NewIssue(RulePageMissing, fmt.Sprintf("no pages found in chapter (%s)", c.Chapter))))
 	}
 
 	var missing []int
@@ -494,29 +573,116 @@ func (c *Chapter) GetPageOrderIssues() []string {
 		}
 
 		if len(seen[i]) > 1 {
-			issues = append(issues, fmt.Sprintf("duplicate pages with weight %d: %s (%s)", i, strings.Join(seen[i], ", "), c.Chapter))
+			issues = append(issues, c.newRecord(seen[i][0], filepath.Base(seen[i][0]), NewIssue(RulePageWeightDuplicate, fmt.Sprintf("duplicate pages with weight %d: %s (%s)", i, strings.Join(seen[i], ", "), c.Chapter))))
 		}
 	}
 
 	if len(missing) > 0 {
-		issues = append(issues, fmt.Sprintf("missing pages with weight %v (%s)", missing, c.Chapter))
+		issues = append(issues, c.newRecord(c.GetIndexFileName(), "_index.md", NewIssue(RulePageWeightGap, fmt.Sprintf("missing pages with weight %v (%s)", missing, c.Chapter))))
 	}
 
-	return issues
+	return filterRecords(issues)
+}
+
+func (c *Chapter) HasIndex() bool {
+	for _, page := range c.Pages {
+		if page.Title == "_index.md" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Chapter) GetIndexFileName() string {
+	for _, page := range c.Pages {
+		if page.Title == "_index.md" {
+			return page.FileName
+		}
+	}
+
+	if len(c.Pages) == 0 {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(c.Pages[0].FileName), "_index.md")
+}
+
+func (c *Chapter) newRecord(filePath, page string, issue Issue) Record {
+	return NewRecord(filePath, c.Course, c.Chapter, page, issue)
+}
+
+// GetInternalLink serves pages below the URL of the chapter, which differs from its directory if its index has a slug.
+func (c *Chapter) GetInternalLink(root string, page Page) string {
+	if page.Title == "_index.md" || page.Content.URL != "" {
+		return page.GetInternalLink(root)
+	}
+
+	for _, index := range c.Pages {
+		if index.Title == "_index.md" && index.Content.Slug != "" {
+			return index.GetInternalLink(root) + page.Content.Slug + "/"
+		}
+	}
+
+	return page.GetInternalLink(root)
+}
+
+// GetAliases resolves relative aliases against the section of the page, just like Hugo.
+func (c *Chapter) GetAliases(root string, page Page) []string {
+	if len(page.Content.Aliases) == 0 {
+		return nil
+	}
+
+	section := path.Dir(strings.TrimSuffix(c.GetInternalLink(root, page), "/"))
+
+	aliases := make([]string, 0, len(page.Content.Aliases))
+	for _, alias := range page.Content.Aliases {
+		if !strings.HasPrefix(alias, "/") {
+			alias = path.Join(section, alias)
+		}
+
+		aliases = append(aliases, cleanPageURL(alias))
+	}
+
+	return aliases
 }
 
-func (c *Chapter) GetLinks() map[string]string {
+func (c *Chapter) GetLinks(root string) map[string]string {
 	links := make(map[string]string)
 
 	for _, page := range c.Pages {
-		for index, link := range page.Content.Links {
-			links[page.FileName+":"+index] = link
+		for _, link := range page.Content.Links {
+			// refs are resolved against all courses, see Courses.GetRefIssues
+			if link.Kind == LinkKindRef {
+				continue
+			}
+
+			links[fmt.Sprintf("%s:%d:%d", page.FileName, link.Position.Line, link.Position.Column)] = resolveInternalLink(c.GetInternalLink(root, page), link.URL)
 		}
 	}
 
 	return links
 }
 
+func SplitLinkSource(source string) (string, int, int) {
+	parts := strings.Split(source, ":")
+	if len(parts) < 3 {
+		return source, 0, 0
+	}
+
+	row, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return source, 0, 0
+	}
+
+	col, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return source, 0, 0
+	}
+
+	return strings.Join(parts[:len(parts)-2], ":"), row, col
+}
+
 type Chapters []*Chapter
 
 func (c Chapters) Add(filePath, courseFN, chapterFN, pageFN string, content Content) Chapters {
@@ -559,23 +725,23 @@ func (c Course) String(statesAllowed map[State]struct{}, printIndex, printNonInd
 	return result
 }
 
-func (c Course) GetChapterOrderIssues() []string {
-	seen := make(map[int][]string, len(c.Chapters))
+func (c Course) GetChapterOrderIssues() []Record {
+	seen := make(map[int][]*Chapter, len(c.Chapters))
 	largestWeight := 0
 
 	for _, chapter := range c.Chapters {
 		weight := chapter.GetWeight()
-		seen[weight] = append(seen[weight], chapter.Chapter)
+		seen[weight] = append(seen[weight], chapter)
 
 		if weight > largestWeight {
 			largestWeight = weight
 		}
 	}
 
-	var issues []string
+	var issues []Record
 
 	if largestWeight < 1 {
-		issues = append(issues, fmt.Sprintf("no chapters found in course (%s)", c.Course))
+		issues = append(issues, c.newRecord(c.GetDirectory(), NewIssue(RuleChapterMissing, fmt.Sprintf("no chapters found in course (%s)", c.Course))))
 	}
 
 	var missing []int
@@ -587,19 +753,24 @@ func (c Course) GetChapterOrderIssues() []string {
 		}
 
 		if len(seen[i]) > 1 {
-			issues = append(issues, fmt.Sprintf("duplicate chapters with weight %d: %s (%s)", i, strings.Join(seen[i], ", "), c.Course))
+			names := make([]string, 0, len(seen[i]))
+			for _, chapter := range seen[i] {
+				names = append(names, chapter.Chapter)
+			}
+
+			issues = append(issues, seen[i][0].newRecord(seen[i][0].GetIndexFileName(), "_index.md", NewIssue(RuleChapterWeightDuplicate, fmt.Sprintf("duplicate chapters with weight %d: %s (%s)", i, strings.Join(names, ", "), c.Course))))
 		}
 	}
 
 	if len(missing) > 0 {
-		issues = append(issues, fmt.Sprintf("missing chapter with weight %v (%s)", missing, c.Course))
+		issues = append(issues, c.newRecord(c.GetDirectory(), NewIssue(RuleChapterWeightGap, fmt.Sprintf("missing chapter with weight %v (%s)", missing, c.Course))))
 	}
 
-	return issues
+	return filterRecords(issues)
 }
 
-func (c Course) GetPageOrderIssues() []string {
-	var issues []string
+func (c Course) GetPageOrderIssues() []Record {
+	var issues []Record
 
 	for _, chapter := range c.Chapters {
 		issues = append(issues, chapter.GetPageOrderIssues()...)
@@ -608,11 +779,25 @@ func (c Course) GetPageOrderIssues() []string {
 	return issues
 }
 
-func (c Course) GetLinks() map[string]string {
+func (c Course) GetDirectory() string {
+	for _, chapter := range c.Chapters {
+		if fileName := chapter.GetIndexFileName(); fileName != "" {
+			return filepath.Dir(filepath.Dir(fileName))
+		}
+	}
+
+	return ""
+}
+
+func (c Course) newRecord(filePath string, issue Issue) Record {
+	return NewRecord(filePath, c.Course, "", "", issue)
+}
+
+func (c Course) GetLinks(root string) map[string]string {
 	allLinks := make(map[string]string)
 
 	for _, chapter := range c.Chapters {
-		for page, link := range chapter.GetLinks() {
+		for page, link := range chapter.GetLinks(root) {
 			allLinks[page] = link
 		}
 	}
@@ -620,8 +805,8 @@ func (c Course) GetLinks() map[string]string {
 	return allLinks
 }
 
-func (c Course) GetErrors() []string {
-	var issues []string
+func (c Course) GetErrors() []Record {
+	var issues []Record
 
 	for _, chapter := range c.Chapters {
 		issues = append(issues, chapter.GetErrors()...)
@@ -689,13 +874,34 @@ func (c Courses) Add(filePath, courseFN, chapterFN, pageFN string, content Conte
 		})
 }
 
-func (c Courses) GetValidInternalLinks() map[string]struct{} {
-	pages := make(map[string]struct{})
+func (c Courses) GetErrors() []Record {
+	var errors []Record
+
+	for _, course := range c {
+		errors = append(errors, course.GetErrors()...)
+	}
+
+	return errors
+}
+
+func (c Courses) GetOrderIssues() []Record {
+	var issues []Record
+
+	for _, course := range c {
+		issues = append(issues, course.GetChapterOrderIssues()...)
+		issues = append(issues, course.GetPageOrderIssues()...)
+	}
+
+	return issues
+}
+
+func (c Courses) GetPages() map[string]Page {
+	pages := make(map[string]Page)
 
 	for _, course := range c {
 		for _, chapter := range course.Chapters {
 			for _, page := range chapter.Pages {
-				pages[page.GetInternalLink()] = struct{}{}
+				pages[page.FileName] = page
 			}
 		}
 	}
@@ -703,6 +909,68 @@ func (c Courses) GetValidInternalLinks() map[string]struct{} {
 	return pages
 }
 
+func (c Courses) GetValidInternalLinks(root string) map[string]struct{} {
+	pages := make(map[string]struct{})
+
+	for link := range c.getURLClaims(root) {
+		pages[link] = struct{}{}
+	}
+
+	return pages
+}
+
+// GetAnchors indexes the anchors by the URLs and aliases of the pages. Collisions prefer the page's own URL.
+func (c Courses) GetAnchors(root string) map[string]map[string]struct{} {
+	anchors := make(map[string]map[string]struct{})
+
+	for link, claims := range c.getURLClaims(root) {
+		claim := claims[0]
+		if index := slices.IndexFunc(claims, func(claim urlClaim) bool { return !claim.alias }); index >= 0 {
+			claim = claims[index]
+		}
+
+		anchors[link] = claim.page.Content.Anchors
+	}
+
+	return anchors
+}
+
+func SplitFragment(link string) (string, string) {
+	index := strings.Index(link, "#")
+	if index < 0 {
+		return link, ""
+	}
+
+	return link[:index], link[index+1:]
+}
+
+// resolveInternalLink resolves a link like browsers do, keeping the trailing slash of links to pages.
+func resolveInternalLink(pageURL, link string) string {
+	if parsed, err := url.Parse(link); err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return link
+	}
+
+	linkPath, fragment := SplitFragment(link)
+	isFile := path.Ext(linkPath) != "" && path.Ext(linkPath) != "."
+
+	trailingSlash := !isFile && (linkPath == "" || strings.HasSuffix(linkPath, "/") || path.Base(linkPath) == "." || path.Base(linkPath) == "..")
+
+	if !strings.HasPrefix(linkPath, "/") {
+		linkPath = path.Join(pageURL, linkPath)
+	}
+
+	linkPath = path.Clean(linkPath)
+	if trailingSlash && linkPath != "/" {
+		linkPath += "/"
+	}
+
+	if strings.Contains(link, "#") {
+		return linkPath + "#" + fragment
+	}
+
+	return linkPath
+}
+
 func column(raw interface{}, width int, color Color) string {
 	content := fmt.Sprint(raw)
 
@@ -1,7 +1,17 @@
 package pkg
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -13,7 +23,21 @@ type DomainClient struct {
 	client   *http.Client
 }
 
-const userAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36"
+const (
+	defaultUserAgent      = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36"
+	defaultRequestTimeout = 30 * time.Second
+	defaultDomainWorkers  = 3
+	// maxRetryAfter caps the waiting time asked for by servers in the Retry-After header
+	maxRetryAfter = 2 * time.Minute
+)
+
+var (
+	httpUserAgent  = defaultUserAgent
+	requestTimeout = defaultRequestTimeout
+	domainWorkers  = defaultDomainWorkers
+)
+
+const maxRedirects = 10
 
 func NewDomainClient(domain string, throttle time.Duration, retries int, backoff time.Duration) *DomainClient {
 	return &DomainClient{
@@ -21,52 +45,262 @@ func NewDomainClient(domain string, throttle time.Duration, retries int, backoff
 		throttle: throttle,
 		retries:  retries,
 		backoff:  backoff,
-		client:   &http.Client{},
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// redirects are followed by fetchPage, so that they can be reported
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
 	}
 }
 
-func (dc *DomainClient) fetchPage(page string) int {
-	var resp *http.Response
-	var err error
+func (dc *DomainClient) do(method, page string) (*http.Response, error) {
+	req, err := http.NewRequest(method, page, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", httpUserAgent)
+
+	resp, err := dc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+func (dc *DomainClient) fetch(page string) (int, string, error) {
+	var lastErr error
 
 	for i := 0; i <= dc.retries; i++ {
-		req, _ := http.NewRequest("GET", page, nil)
-		req.Header.Set("User-Agent", userAgent)
+		// no backoff after the last attempt, its error is returned right away
+		last := i == dc.retries
 
-		resp, err = dc.client.Do(req)
-		if err == nil {
-			defer resp.Body.Close()
+		// some servers answer HEAD with an error status, but a stalled host is not waited for twice
+		resp, err := dc.do(http.MethodHead, page)
+		if err == nil && resp.StatusCode >= 400 && resp.StatusCode != http.StatusTooManyRequests {
+			resp, err = dc.do(http.MethodGet, page)
+		}
 
-			if resp.StatusCode == http.StatusTooManyRequests {
-				time.Sleep(dc.throttle)
+		if err != nil {
+			lastErr = err
+			if !last {
+				time.Sleep(dc.backoff * time.Duration(1<<(i*2)))
+			}
+
+			continue
+		}
 
-				continue
+		retryAfter := resp.Header.Get("Retry-After")
+		if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode == http.StatusServiceUnavailable && retryAfter != "") {
+			lastErr = fmt.Errorf("%w, status code: %d", errTooManyRequests, resp.StatusCode)
+			if !last {
+				time.Sleep(getRetryAfter(retryAfter, dc.throttle))
 			}
 
-			return resp.StatusCode
+			continue
 		}
 
-		time.Sleep(dc.backoff * time.Duration(1<<(i*2)))
+		return resp.StatusCode, resp.Header.Get("Location"), nil
+	}
+
+	return 0, "", lastErr
+}
+
+func getRetryAfter(value string, fallback time.Duration) time.Duration {
+	wait := fallback
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
 	}
 
-	return 0
+	if wait <= 0 {
+		return fallback
+	}
+
+	return min(wait, maxRetryAfter)
+}
+
+func (dc *DomainClient) fetchPage(page string) Result {
+	result := Result{URL: page}
+
+	visited := map[string]struct{}{page: {}}
+	current := page
+
+	for redirects := 0; ; redirects++ {
+		code, location, err := dc.fetch(current)
+		result.Code = code
+		result.FinalURL = current
+
+		if err != nil {
+			result.ErrorKind, result.Error = getErrorKind(err), err.Error()
+
+			return result
+		}
+
+		if !isRedirect(code) || location == "" {
+			return result
+		}
+
+		if result.Redirect == 0 {
+			result.Redirect = code
+		}
+
+		next, err := resolveLocation(current, location)
+		if err != nil {
+			return result
+		}
+
+		result.FinalURL = next
+
+		if _, ok := visited[next]; ok {
+			result.Loop = true
+
+			return result
+		}
+
+		if redirects >= maxRedirects {
+			result.Code = 0
+			result.ErrorKind, result.Error = ErrorTooManyRedirects, fmt.Sprintf("stopped after %d redirects", maxRedirects)
+
+			return result
+		}
+
+		visited[next] = struct{}{}
+		current = next
+	}
+}
+
+func isRedirect(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+
+	return false
+}
+
+func resolveLocation(current, location string) (string, error) {
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+
+	next, err := base.Parse(location)
+	if err != nil {
+		return "", err
+	}
+
+	return next.String(), nil
+}
+
+type ErrorKind string
+
+const (
+	ErrorDNS               ErrorKind = "dns"
+	ErrorConnectionRefused ErrorKind = "connection refused"
+	ErrorTLS               ErrorKind = "tls"
+	ErrorTimeout           ErrorKind = "timeout"
+	ErrorTooManyRedirects  ErrorKind = "too many redirects"
+	ErrorTooManyRequests   ErrorKind = "too many requests"
+	ErrorOther             ErrorKind = "other"
+)
+
+var errTooManyRequests = errors.New("too many requests")
+
+func getErrorKind(err error) ErrorKind {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var hostnameErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+
+	switch {
+	case errors.Is(err, errTooManyRequests):
+		return ErrorTooManyRequests
+	case errors.As(err, &dnsErr):
+		return ErrorDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorConnectionRefused
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &hostnameErr),
+		errors.As(err, &authorityErr), errors.As(err, &invalidErr):
+		return ErrorTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTimeout
+	}
+
+	return ErrorOther
 }
 
 type Result struct {
-	URL  string
-	Code int
+	URL      string
+	Code     int
+	FinalURL string
+	// Redirect is the status code of the first redirect, 0 if the page was not redirected
+	Redirect  int
+	Loop      bool
+	ErrorKind ErrorKind
+	Error     string
+}
+
+func (r Result) Issues() []Issue {
+	if r.Loop {
+		return []Issue{NewIssue(RuleExternalRedirectLoop, fmt.Sprintf("redirect loop: %s", r.URL))}
+	}
+
+	if r.ErrorKind != "" {
+		return []Issue{NewIssue(RuleExternalLinkError, fmt.Sprintf("request failed (%s): %s, error: %s", r.ErrorKind, r.URL, r.Error))}
+	}
+
+	var issues []Issue
+
+	if r.Code != http.StatusOK {
+		issues = append(issues, NewIssue(RuleExternalLinkStatus, fmt.Sprintf("unexpected status code %d: %s", r.Code, r.URL)))
+	}
+
+	if r.Redirect == http.StatusMovedPermanently || r.Redirect == http.StatusPermanentRedirect {
+		issues = append(issues, NewIssue(RuleExternalLinkMoved, fmt.Sprintf("moved permanently (%d): %s => %s", r.Redirect, r.URL, r.FinalURL)))
+	}
+
+	if r.Redirect != 0 && !isSameSite(r.URL, r.FinalURL) {
+		issues = append(issues, NewIssue(RuleExternalRedirectDomain, fmt.Sprintf("redirected to another domain: %s => %s", r.URL, r.FinalURL)))
+	}
+
+	return issues
+}
+
+func isSameSite(a, b string) bool {
+	urlA, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	urlB, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimPrefix(urlA.Host, "www.") == strings.TrimPrefix(urlB.Host, "www.")
 }
 
 func (dc *DomainClient) FetchPages(urls []string) []Result {
 	results := make([]Result, 0, len(urls))
 
-	urlChannel := make(chan string, 3)
-	resultChannel := make(chan Result, 3)
+	urlChannel := make(chan string, domainWorkers)
+	resultChannel := make(chan Result, domainWorkers)
 
-	for w := 1; w <= 3; w++ {
+	for w := 1; w <= domainWorkers; w++ {
 		go func(urlChannel <-chan string, resultChannel chan<- Result) {
 			for url := range urlChannel {
-				resultChannel <- Result{URL: url, Code: dc.fetchPage(url)}
+				resultChannel <- dc.fetchPage(url)
 			}
 		}(urlChannel, resultChannel)
 	}
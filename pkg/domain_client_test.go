@@ -0,0 +1,345 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainClient_FetchPages_Redirects(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.Handle("/moved", http.RedirectHandler("/ok", http.StatusMovedPermanently))
+	mux.Handle("/moved-308", http.RedirectHandler("/moved", http.StatusPermanentRedirect))
+	mux.Handle("/temporary", http.RedirectHandler("/ok", http.StatusFound))
+	mux.Handle("/moved-gone", http.RedirectHandler("/gone", http.StatusMovedPermanently))
+	mux.Handle("/loop-a", http.RedirectHandler("/loop-b", http.StatusFound))
+	mux.Handle("/loop-b", http.RedirectHandler("/loop-a", http.StatusFound))
+	mux.Handle("/away", http.RedirectHandler(other.URL+"/login", http.StatusFound))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		want      Result
+		wantRules []Rule
+	}{
+		{
+			name: "ok",
+			path: "/ok",
+			want: Result{Code: 200, FinalURL: server.URL + "/ok"},
+		},
+		{
+			name:      "not found",
+			path:      "/gone",
+			want:      Result{Code: 404, FinalURL: server.URL + "/gone"},
+			wantRules: []Rule{RuleExternalLinkStatus},
+		},
+		{
+			name:      "moved permanently",
+			path:      "/moved",
+			want:      Result{Code: 200, FinalURL: server.URL + "/ok", Redirect: 301},
+			wantRules: []Rule{RuleExternalLinkMoved},
+		},
+		{
+			name:      "permanent redirect chain",
+			path:      "/moved-308",
+			want:      Result{Code: 200, FinalURL: server.URL + "/ok", Redirect: 308},
+			wantRules: []Rule{RuleExternalLinkMoved},
+		},
+		{
+			name: "temporary redirect",
+			path: "/temporary",
+			want: Result{Code: 200, FinalURL: server.URL + "/ok", Redirect: 302},
+		},
+		{
+			name:      "moved to a missing page",
+			path:      "/moved-gone",
+			want:      Result{Code: 404, FinalURL: server.URL + "/gone", Redirect: 301},
+			wantRules: []Rule{RuleExternalLinkStatus, RuleExternalLinkMoved},
+		},
+		{
+			name:      "loop",
+			path:      "/loop-a",
+			want:      Result{Code: 302, FinalURL: server.URL + "/loop-a", Redirect: 302, Loop: true},
+			wantRules: []Rule{RuleExternalRedirectLoop},
+		},
+		{
+			name:      "other domain",
+			path:      "/away",
+			want:      Result{Code: 200, FinalURL: other.URL + "/login", Redirect: 302},
+			wantRules: []Rule{RuleExternalRedirectDomain},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewDomainClient(server.URL, time.Millisecond, 0, time.Millisecond)
+
+			// execute
+			results := client.FetchPages([]string{server.URL + tt.path})
+
+			// verify
+			want := tt.want
+			want.URL = server.URL + tt.path
+			assert.Equal(t, []Result{want}, results)
+
+			var rules []Rule
+			for _, issue := range results[0].Issues() {
+				rules = append(rules, issue.Rule)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func Test_isSameSite(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{name: "same", a: "http://example.com/a", b: "https://example.com/b", want: true},
+		{name: "www", a: "https://example.com/a", b: "https://www.example.com/a", want: true},
+		{name: "other", a: "https://example.com/a", b: "https://parked.example.net/", want: false},
+		{name: "subdomain", a: "https://example.com/a", b: "https://login.example.com/", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := isSameSite(tt.a, tt.b)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDomainClient_FetchPages_Requests(t *testing.T) {
+	defer ApplyConfig(DefaultConfig())
+
+	config := DefaultConfig()
+	config.UserAgent = "content-checker-test"
+	config.RequestTimeout = "100ms"
+	ApplyConfig(config)
+
+	var lock sync.Mutex
+	var requests []string
+
+	limited := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/head", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/limited", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		limited++
+		if limited == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/stalled", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.UserAgent())
+		lock.Unlock()
+
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		wantCode     int
+		wantRequests []string
+		wantMinTime  time.Duration
+	}{
+		{
+			name:         "head",
+			path:         "/head",
+			wantCode:     200,
+			wantRequests: []string{"HEAD /head content-checker-test"},
+		},
+		{
+			name:         "get fallback",
+			path:         "/no-head",
+			wantCode:     200,
+			wantRequests: []string{"HEAD /no-head content-checker-test", "GET /no-head content-checker-test"},
+		},
+		{
+			name:         "retry after",
+			path:         "/limited",
+			wantCode:     200,
+			wantRequests: []string{"HEAD /limited content-checker-test", "HEAD /limited content-checker-test"},
+			wantMinTime:  time.Second,
+		},
+		{
+			name:     "timeout",
+			path:     "/stalled",
+			wantCode: 0,
+			wantRequests: []string{
+				"HEAD /stalled content-checker-test",
+				"HEAD /stalled content-checker-test",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			client := NewDomainClient(server.URL, time.Millisecond, 1, time.Millisecond)
+			start := time.Now()
+
+			// execute
+			results := client.FetchPages([]string{server.URL + tt.path})
+
+			// verify
+			assert.Equal(t, tt.wantCode, results[0].Code)
+			assert.GreaterOrEqual(t, time.Since(start), tt.wantMinTime)
+
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestDomainClient_fetch_NoBackoffAfterLastAttempt(t *testing.T) {
+	defer ApplyConfig(DefaultConfig())
+
+	config := DefaultConfig()
+	config.RequestTimeout = "50ms"
+	ApplyConfig(config)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := NewDomainClient(server.URL, time.Millisecond, 1, time.Second)
+	start := time.Now()
+
+	// execute
+	code, _, err := client.fetch(server.URL)
+
+	// verify
+	assert.Equal(t, 0, code)
+	assert.Error(t, err)
+	// a single backoff between the two attempts, none after the last one
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func Test_getRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 5 * time.Second},
+		{name: "seconds", value: "30", want: 30 * time.Second},
+		{name: "capped", value: "3600", want: maxRetryAfter},
+		{name: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 5 * time.Second},
+		{name: "invalid", value: "soon", want: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := getRetryAfter(tt.value, 5*time.Second)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDomainClient_FetchPages_Errors(t *testing.T) {
+	defer ApplyConfig(DefaultConfig())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stalled", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	})
+	mux.HandleFunc("/endless/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path+"x", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tlsServer := httptest.NewTLSServer(mux)
+	defer tlsServer.Close()
+
+	closed := httptest.NewServer(mux)
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		timeout string
+		want    ErrorKind
+	}{
+		{name: "dns", url: "http://content-checker.invalid/", timeout: "10s", want: ErrorDNS},
+		{name: "connection refused", url: closed.URL + "/", timeout: "10s", want: ErrorConnectionRefused},
+		{name: "tls", url: tlsServer.URL + "/", timeout: "10s", want: ErrorTLS},
+		{name: "timeout", url: server.URL + "/stalled", timeout: "100ms", want: ErrorTimeout},
+		{name: "too many redirects", url: server.URL + "/endless/", timeout: "10s", want: ErrorTooManyRedirects},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.RequestTimeout = tt.timeout
+			ApplyConfig(config)
+
+			client := NewDomainClient(server.URL, time.Millisecond, 0, time.Millisecond)
+
+			// execute
+			results := client.FetchPages([]string{tt.url})
+
+			// verify
+			assert.Equal(t, 0, results[0].Code)
+			assert.Equal(t, tt.want, results[0].ErrorKind)
+			assert.NotEmpty(t, results[0].Error)
+
+			issues := results[0].Issues()
+			require.Len(t, issues, 1)
+			assert.Equal(t, RuleExternalLinkError, issues[0].Rule)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package pkg
 
 import (
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCourses_Add(t *testing.T) {
@@ -464,3 +466,114 @@ func Test_CalculateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_GetIssues_Positions(t *testing.T) {
+	rawContent := `+++
+title = "Loops!"
+weight = 20
+state = "complete"
+slug = "loop"
+tags = ["go"]
+audience = "all"
+audienceImportance = "essential"
++++
+
+Summary
+-------
+
+- foo
+
+Related Videos
+--------------
+
+### Long one
+
+{{< time 150 >}} {{< badge-extra >}}
+
+{{< youtube abc >}}
+
+Exercises
+---------
+
+- bar
+`
+
+	content, err := ParseMarkdown(rawContent)
+	require.NoError(t, err)
+
+	// execute
+	issues := content.GetIssues("content/go/basics/20-loop.md", "go", "basics", "20-loop.md")
+
+	// verify
+	got := make(map[Rule]Position, len(issues))
+	for _, issue := range issues {
+		got[issue.Rule] = issue.Position
+	}
+
+	assert.Equal(t, map[Rule]Position{
+		RuleBadgeLength:   {Line: 21, Column: 1},
+		RuleStateMismatch: {Line: 4, Column: 1},
+		RuleTopicsMissing: {},
+		RuleSlugMismatch:  {Line: 5, Column: 1},
+	}, got)
+}
+
+func Test_resolveInternalLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageURL string
+		link    string
+		want    string
+	}{
+		{name: "absolute", pageURL: "/go/basics/loops/", link: "/go/basics/variables/", want: "/go/basics/variables/"},
+		{name: "absolute with dot segments", pageURL: "/go/basics/loops/", link: "/go/advanced/../basics/variables", want: "/go/basics/variables"},
+		{name: "fragment", pageURL: "/go/basics/loops/", link: "#exercises", want: "/go/basics/loops/#exercises"},
+		{name: "parent", pageURL: "/go/basics/loops/", link: "../variables/", want: "/go/basics/variables/"},
+		{name: "parent with fragment", pageURL: "/go/basics/loops/", link: "../variables/#summary", want: "/go/basics/variables/#summary"},
+		{name: "child of section", pageURL: "/go/basics/", link: "variables", want: "/go/basics/variables"},
+		{name: "current directory", pageURL: "/go/basics/", link: "./", want: "/go/basics/"},
+		{name: "up to the root", pageURL: "/go/basics/", link: "../../..", want: "/"},
+		{name: "external", pageURL: "/go/basics/", link: "https://go.dev/doc/", want: "https://go.dev/doc/"},
+		{name: "mailto", pageURL: "/go/basics/", link: "mailto:info@example.com", want: "mailto:info@example.com"},
+		{name: "file", pageURL: "/go/basics/loops/", link: "../data.sql", want: "/go/basics/data.sql"},
+		{name: "file of bundle", pageURL: "/go/basics/loops/", link: "./diagram.png#top", want: "/go/basics/loops/diagram.png#top"},
+		{name: "absolute file", pageURL: "/go/basics/loops/", link: "/images/../logo.png", want: "/logo.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := resolveInternalLink(tt.pageURL, tt.link)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChapter_GetLinks(t *testing.T) {
+	content, err := ParseMarkdown("+++\ntitle = \"Loops\"\nslug = \"loops\"\n+++\n\nSee [variables](/go/basics/variables/?tab=1#exercises) and [exercises](#exercises).\n")
+	require.NoError(t, err)
+
+	chapter := Chapter{Pages: Pages{{FileName: "content/go/basics/20-loops.md", Content: content}}}
+
+	// execute
+	got := chapter.GetLinks(".")
+
+	// verify
+	assert.Equal(t, map[string]string{
+		"content/go/basics/20-loops.md:6:17": "/go/basics/variables/#exercises",
+		"content/go/basics/20-loops.md:6:72": "/go/basics/loops/#exercises",
+	}, got)
+}
+
+// newTestPage returns a page of the file, the course and the chapter being its parent directories, just like for the
+// pages found by the crawler.
+func newTestPage(fileName string, content Content) Page {
+	page := Page{FileName: fileName, Title: path.Base(fileName), Content: content}
+
+	dir := path.Dir(fileName)
+	page.Chapter = path.Base(dir)
+	page.Course = path.Base(path.Dir(dir))
+
+	return page
+}
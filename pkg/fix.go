@@ -0,0 +1,350 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PageFix only ever changes the front matter, the body is left untouched.
+type PageFix struct {
+	FilePath    string
+	NewFilePath string
+	Original    string
+	Fixed       string
+	Changes     []string
+}
+
+func newPageFix(filePath, rawContent string) PageFix {
+	return PageFix{
+		FilePath:    filePath,
+		NewFilePath: filePath,
+		Original:    rawContent,
+		Fixed:       rawContent,
+	}
+}
+
+func (f PageFix) IsEmpty() bool {
+	return len(f.Changes) == 0
+}
+
+func (f PageFix) IsRename() bool {
+	return f.NewFilePath != f.FilePath
+}
+
+func (f PageFix) Diff() string {
+	result := ""
+
+	if f.IsRename() {
+		result += fmt.Sprintf("rename %s => %s%s", f.FilePath, f.NewFilePath, EOL)
+	}
+
+	return result + UnifiedDiff(f.FilePath, f.NewFilePath, f.Original, f.Fixed)
+}
+
+// Apply never overwrites an existing file by a rename.
+func (f PageFix) Apply() error {
+	if f.Fixed != f.Original {
+		info, err := os.Stat(f.FilePath)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(f.FilePath, []byte(f.Fixed), info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	if !f.IsRename() {
+		return nil
+	}
+
+	if _, err := os.Stat(f.NewFilePath); err == nil {
+		return fmt.Errorf("cannot rename %s, %s already exists", f.FilePath, f.NewFilePath)
+	}
+
+	return os.Rename(f.FilePath, f.NewFilePath)
+}
+
+func (f *PageFix) setValue(key, value, change string) error {
+	fixed, err := setFrontMatterValue(f.Fixed, key, value)
+	if err != nil {
+		return err
+	}
+
+	f.Fixed = fixed
+	f.Changes = append(f.Changes, change)
+
+	return nil
+}
+
+// GetFix only fixes issues with a single obvious solution. Disabled and suppressed rules are not fixed.
+func (p Page) GetFix(rawContent string) (PageFix, error) {
+	fix := newPageFix(p.FileName, rawContent)
+
+	found := make(map[Rule]bool)
+	for _, issue := range p.GetIssues() {
+		found[issue.Rule] = true
+	}
+
+	c := p.Content
+
+	slug := c.Slug
+	if found[RuleSlugMismatch] {
+		slug = slugify(c.Title)
+
+		if err := fix.setValue("slug", strconv.Quote(slug), fmt.Sprintf("slug: %s => %s", c.Slug, slug)); err != nil {
+			return fix, err
+		}
+	}
+
+	if found[RuleTagCase] {
+		tags := make([]string, 0, len(c.Tags))
+		for _, tag := range c.Tags {
+			tags = append(tags, strings.ToLower(tag))
+		}
+
+		if err := fix.setValue("tags", formatList(tags), fmt.Sprintf("tags: %v => %v", c.Tags, tags)); err != nil {
+			return fix, err
+		}
+	}
+
+	if found[RuleStateMismatch] {
+		state, _ := c.Body.CalculateState()
+
+		if err := fix.setValue("state", strconv.Quote(string(state)), fmt.Sprintf("state: %s => %s", c.State, state)); err != nil {
+			return fix, err
+		}
+	}
+
+	if found[RuleFileNameWeight] || found[RuleFileNameSlug] || slug != c.Slug {
+		fix.rename(p, c.Weight, slug)
+	}
+
+	return fix, nil
+}
+
+func (f *PageFix) rename(p Page, weight, slug string) {
+	if _, isIndex := p.Content.Body.(*IndexBody); isIndex || weight == "" || slug == "" {
+		return
+	}
+
+	fileName := fmt.Sprintf("%s-%s.md", weight, slug)
+	if fileName == p.Title {
+		return
+	}
+
+	f.NewFilePath = filepath.Join(filepath.Dir(p.FileName), fileName)
+	f.Changes = append(f.Changes, fmt.Sprintf("file name: %s => %s", p.Title, fileName))
+}
+
+func (p Page) GetWeightFix(rawContent string, weight int) (PageFix, error) {
+	fix := newPageFix(p.FileName, rawContent)
+
+	newWeight := strconv.Itoa(weight)
+	if newWeight != p.Content.Weight {
+		if err := fix.setValue("weight", newWeight, fmt.Sprintf("weight: %s => %s", p.Content.Weight, newWeight)); err != nil {
+			return fix, err
+		}
+	}
+
+	fix.rename(p, newWeight, p.Content.Slug)
+
+	return fix, nil
+}
+
+func formatList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, strconv.Quote(item))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// the keys after a TOML table header belong to the table
+var regexTOMLTable = regexp.MustCompile(`^\s*\[`)
+
+// setFrontMatterValue only edits top-level keys. Values spanning multiple lines are replaced as a whole.
+func setFrontMatterValue(rawContent, key, value string) (string, error) {
+	format, ok := getFrontMatterFormat(rawContent)
+	if !ok {
+		return rawContent, errors.New("front matter not found")
+	}
+
+	if format == JSONFrontMatter {
+		return rawContent, errJSONFrontMatterEdit
+	}
+
+	lines := strings.SplitAfter(rawContent, "\n")
+
+	end := slices.IndexFunc(lines[1:], func(line string) bool {
+		return strings.TrimRight(line, "\r\n") == format.delimiter()
+	}) + 1
+	if end == 0 {
+		return rawContent, errors.New("front matter is not closed")
+	}
+
+	regex := format.keyRegex()
+	insertAt := 1
+
+	for i := 1; i < end; i++ {
+		row := strings.TrimRight(lines[i], "\r\n")
+
+		if format == TOMLFrontMatter && regexTOMLTable.MatchString(row) {
+			break
+		}
+
+		matches := regex.FindStringSubmatchIndex(row)
+		if len(matches) != 6 {
+			continue
+		}
+
+		last, valueEnd := getValueEnd(format, lines, i, end, matches[4])
+
+		if row[matches[2]:matches[3]] == key {
+			// YAML block values start on the next line, so there may be no separator to keep
+			prefix := row[:matches[4]]
+			if matches[4] == matches[5] {
+				prefix = row[:matches[3]] + format.separator()
+			}
+
+			// comments after the value are kept, just like the line ending
+			lines = slices.Replace(lines, i, last+1, prefix+value+lines[last][valueEnd:])
+
+			return checkFrontMatter(rawContent, strings.Join(lines, ""), key)
+		}
+
+		insertAt = last + 1
+		i = last
+	}
+
+	eol := lines[insertAt-1][len(strings.TrimRight(lines[insertAt-1], "\r\n")):]
+	lines = slices.Insert(lines, insertAt, key+format.separator()+value+eol)
+
+	return checkFrontMatter(rawContent, strings.Join(lines, ""), key)
+}
+
+// checkFrontMatter refuses edits which would no longer decode, so that a fix never breaks a page.
+func checkFrontMatter(original, edited, key string) (string, error) {
+	format, header, _, _, err := splitMarkdown(edited)
+	if err != nil {
+		return original, fmt.Errorf("%s could not be set, err: %w", key, err)
+	}
+
+	_, issues := decodeFrontMatter(format, header, nil)
+	for _, issue := range issues {
+		if issue.Rule == RuleFrontMatterSyntax {
+			return original, fmt.Errorf("%s could not be set without breaking the front matter, %s", key, issue.Message)
+		}
+	}
+
+	return edited, nil
+}
+
+// getValueEnd returns the last line of the value and the column where the value ends on it, before any comment.
+func getValueEnd(format FrontMatterFormat, lines []string, i, end, start int) (int, int) {
+	if format == TOMLFrontMatter {
+		return tomlValueEnd(lines, i, end, start)
+	}
+
+	return yamlValueEnd(lines, i, end, start)
+}
+
+func yamlValueEnd(lines []string, i, end, start int) (int, int) {
+	last := i
+
+	for j := i + 1; j < end; j++ {
+		row := strings.TrimRight(lines[j], "\r\n")
+
+		if strings.TrimSpace(row) == "" {
+			continue
+		}
+
+		if row[0] != ' ' && row[0] != '\t' && row[0] != '-' {
+			break
+		}
+
+		last = j
+	}
+
+	if last != i {
+		start = 0
+	}
+
+	row := strings.TrimRight(lines[last], "\r\n")
+	quote := byte(0)
+
+	for j := start; j < len(row); j++ {
+		switch {
+		case quote != 0:
+			if row[j] == quote {
+				quote = 0
+			} else if row[j] == '\\' && quote == '"' {
+				j++
+			}
+		case (row[j] == '"' || row[j] == '\'') && (j == start || strings.IndexByte(" [{,", row[j-1]) >= 0):
+			quote = row[j]
+		case row[j] == '#' && (j == start || row[j-1] == ' ' || row[j-1] == '\t'):
+			return last, len(strings.TrimRight(row[:j], " \t"))
+		}
+	}
+
+	return last, len(strings.TrimRight(row, " \t"))
+}
+
+func tomlValueEnd(lines []string, i, end, start int) (int, int) {
+	depth := 0
+	quote := ""
+
+	for {
+		row := strings.TrimRight(lines[i], "\r\n")
+		valueEnd := len(strings.TrimRight(row, " \t"))
+
+		for j := start; j < len(row); j++ {
+			if quote != "" {
+				switch {
+				case strings.HasPrefix(row[j:], quote):
+					j += len(quote) - 1
+					quote = ""
+				case row[j] == '\\' && quote[0] == '"':
+					j++
+				}
+
+				continue
+			}
+
+			switch {
+			case strings.HasPrefix(row[j:], `"""`), strings.HasPrefix(row[j:], "'''"):
+				quote = row[j : j+3]
+				j += 2
+			case row[j] == '"', row[j] == '\'':
+				quote = row[j : j+1]
+			case row[j] == '#':
+				valueEnd = len(strings.TrimRight(row[:j], " \t"))
+				j = len(row)
+			case row[j] == '[', row[j] == '{':
+				depth++
+			case row[j] == ']', row[j] == '}':
+				depth--
+			}
+		}
+
+		// single-line strings end with the line, even if they are not closed
+		if len(quote) == 1 {
+			quote = ""
+		}
+
+		if (depth <= 0 && quote == "") || i+1 >= end {
+			return i, valueEnd
+		}
+
+		i++
+		start = 0
+	}
+}
@@ -0,0 +1,243 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixRawContent = `+++
+title = "Loops!"
+weight = 20
+state = "complete"
+slug   = "loop"
+tags = ["go", "Basics"]
+audience = "all"
+audienceImportance = "essential"
++++
+
+Summary
+-------
+
+- foo
+`
+
+func TestPage_GetFix(t *testing.T) {
+	content, err := ParseMarkdown(fixRawContent)
+	require.NoError(t, err)
+
+	page := Page{FileName: "content/go/basics/20-loop.md", Course: "go", Chapter: "basics", Title: "20-loop.md", Content: content}
+
+	// execute
+	got, err := page.GetFix(fixRawContent)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "content/go/basics/20-loops.md", got.NewFilePath)
+	assert.Equal(t, []string{
+		"slug: loop => loops",
+		"tags: [go Basics] => [go basics]",
+		"state: complete => stub",
+		"file name: 20-loop.md => 20-loops.md",
+	}, got.Changes)
+	assert.Equal(t, `+++
+title = "Loops!"
+weight = 20
+state = "stub"
+slug   = "loops"
+tags = ["go", "basics"]
+audience = "all"
+audienceImportance = "essential"
++++
+
+Summary
+-------
+
+- foo
+`, got.Fixed)
+}
+
+func TestPageFix_Apply(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "20-loop.md")
+	newPath := filepath.Join(dir, "20-loops.md")
+
+	require.NoError(t, os.WriteFile(oldPath, []byte("old"), 0o644))
+
+	fix := PageFix{FilePath: oldPath, NewFilePath: newPath, Original: "old", Fixed: "new", Changes: []string{"changed"}}
+
+	// execute
+	err := fix.Apply()
+
+	// verify
+	require.NoError(t, err)
+	assert.NoFileExists(t, oldPath)
+
+	got, err := os.ReadFile(newPath)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(got))
+}
+
+func Test_setFrontMatterValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawContent string
+		key        string
+		value      string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "replace",
+			rawContent: "+++\nslug = \"foo\"\n+++\n\nslug = \"body\"\n",
+			key:        "slug",
+			value:      `"bar"`,
+			want:       "+++\nslug = \"bar\"\n+++\n\nslug = \"body\"\n",
+		},
+		{
+			name:       "add",
+			rawContent: "+++\ntitle = \"Foo\"\n+++\n\nbody\n",
+			key:        "slug",
+			value:      `"foo"`,
+			want:       "+++\ntitle = \"Foo\"\nslug = \"foo\"\n+++\n\nbody\n",
+		},
+		{
+			name:       "windows line endings",
+			rawContent: "+++\r\nslug = \"foo\"\r\n+++\r\n",
+			key:        "slug",
+			value:      `"bar"`,
+			want:       "+++\r\nslug = \"bar\"\r\n+++\r\n",
+		},
+		{
+			name:       "multi-line array",
+			rawContent: "+++\ntags = [\n  \"Go\", # the language\n  \"basics\",\n]\nslug = \"foo\"\n+++\n",
+			key:        "tags",
+			value:      `["go", "basics"]`,
+			want:       "+++\ntags = [\"go\", \"basics\"]\nslug = \"foo\"\n+++\n",
+		},
+		{
+			name:       "inline comment",
+			rawContent: "+++\nstate = \"draft\" # wip\ntitle = \"Foo\"\n+++\n",
+			key:        "state",
+			value:      `"published"`,
+			want:       "+++\nstate = \"published\" # wip\ntitle = \"Foo\"\n+++\n",
+		},
+		{
+			name:       "inline comment after multi-line array",
+			rawContent: "+++\ntags = [\n  \"Go\",\n] # topics\n+++\n",
+			key:        "tags",
+			value:      `["go"]`,
+			want:       "+++\ntags = [\"go\"] # topics\n+++\n",
+		},
+		{
+			name:       "yaml inline comment",
+			rawContent: "---\nstate: 'draft # 1' # wip\ntitle: Foo\n---\n",
+			key:        "state",
+			value:      "published",
+			want:       "---\nstate: published # wip\ntitle: Foo\n---\n",
+		},
+		{
+			name:       "multi-line string",
+			rawContent: "+++\ntitle = \"\"\"Foo\n[bar]\n\"\"\"\n+++\n",
+			key:        "slug",
+			value:      `"foo"`,
+			want:       "+++\ntitle = \"\"\"Foo\n[bar]\n\"\"\"\nslug = \"foo\"\n+++\n",
+		},
+		{
+			name:       "table",
+			rawContent: "+++\ntitle = \"Foo\"\nslug = \"foo\"\n\n[params]\nslug = \"param\"\n+++\n",
+			key:        "slug",
+			value:      `"bar"`,
+			want:       "+++\ntitle = \"Foo\"\nslug = \"bar\"\n\n[params]\nslug = \"param\"\n+++\n",
+		},
+		{
+			name:       "add before table",
+			rawContent: "+++\ntitle = \"Foo\"\n\n[params]\nslug = \"param\"\n+++\n",
+			key:        "slug",
+			value:      `"foo"`,
+			want:       "+++\ntitle = \"Foo\"\nslug = \"foo\"\n\n[params]\nslug = \"param\"\n+++\n",
+		},
+		{
+			name:       "yaml",
+			rawContent: "---\ntitle: Foo\nslug: foo\n---\n\nbody\n",
+			key:        "slug",
+			value:      `"bar"`,
+			want:       "---\ntitle: Foo\nslug: \"bar\"\n---\n\nbody\n",
+		},
+		{
+			name:       "yaml block list",
+			rawContent: "---\ntags:\n  - Go\n  - basics\nslug: foo\n---\n",
+			key:        "tags",
+			value:      `["go", "basics"]`,
+			want:       "---\ntags: [\"go\", \"basics\"]\nslug: foo\n---\n",
+		},
+		{
+			name:       "yaml unindented block list",
+			rawContent: "---\ntags:\n- Go\n\n- basics\n\nslug: foo\n---\n",
+			key:        "tags",
+			value:      `["go", "basics"]`,
+			want:       "---\ntags: [\"go\", \"basics\"]\n\nslug: foo\n---\n",
+		},
+		{
+			name:       "yaml nested key",
+			rawContent: "---\nparams:\n  slug: param\ntitle: Foo\n---\n",
+			key:        "slug",
+			value:      `"foo"`,
+			want:       "---\nparams:\n  slug: param\ntitle: Foo\nslug: \"foo\"\n---\n",
+		},
+		{
+			name:       "yaml add",
+			rawContent: "---\ntitle: Foo\n---\n",
+			key:        "weight",
+			value:      "10",
+			want:       "---\ntitle: Foo\nweight: 10\n---\n",
+		},
+		{
+			name:       "broken result",
+			rawContent: "+++\nslug = \"foo\"\n+++\n",
+			key:        "slug",
+			value:      `"bar`,
+			want:       "+++\nslug = \"foo\"\n+++\n",
+			wantErr:    true,
+		},
+		{
+			name:       "json",
+			rawContent: "{\n  \"slug\": \"foo\"\n}\n",
+			key:        "slug",
+			value:      `"bar"`,
+			want:       "{\n  \"slug\": \"foo\"\n}\n",
+			wantErr:    true,
+		},
+		{
+			name:       "no front matter",
+			rawContent: "body\n",
+			key:        "slug",
+			value:      `"foo"`,
+			want:       "body\n",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got, err := setFrontMatterValue(tt.rawContent, tt.key, tt.value)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			content, err := ParseMarkdown(got)
+			require.NoError(t, err)
+			assert.Empty(t, content.FrontMatterIssues)
+		})
+	}
+}
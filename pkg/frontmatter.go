@@ -0,0 +1,339 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type FrontMatterFormat string
+
+const (
+	TOMLFrontMatter FrontMatterFormat = "toml"
+	YAMLFrontMatter FrontMatterFormat = "yaml"
+	JSONFrontMatter FrontMatterFormat = "json"
+)
+
+func ParseFrontMatterFormat(raw string) (FrontMatterFormat, error) {
+	switch format := FrontMatterFormat(raw); format {
+	case "", TOMLFrontMatter, YAMLFrontMatter, JSONFrontMatter:
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unknown front matter format: %s", raw)
+}
+
+func (f FrontMatterFormat) delimiter() string {
+	switch f {
+	case TOMLFrontMatter:
+		return "+++"
+	case YAMLFrontMatter:
+		return "---"
+	}
+
+	return ""
+}
+
+// firstLine is 0 for JSON front matter, as its values start with the opening brace.
+func (f FrontMatterFormat) firstLine() int {
+	if f == JSONFrontMatter {
+		return 1
+	}
+
+	return 2
+}
+
+func (f FrontMatterFormat) separator() string {
+	if f == YAMLFrontMatter {
+		return ": "
+	}
+
+	return " = "
+}
+
+func (f FrontMatterFormat) keyRegex() *regexp.Regexp {
+	switch f {
+	case YAMLFrontMatter:
+		return regexYAMLKey
+	case JSONFrontMatter:
+		return regexJSONKey
+	}
+
+	return regexHeader
+}
+
+var regexYAMLKey = regexp.MustCompile(`^([^\s#:'"-][^:]*?)\s*:\s*(.*)$`)
+var regexJSONKey = regexp.MustCompile(`^\s*"([^"]+)"\s*:\s*(.*)$`)
+
+func getFrontMatterFormat(content string) (FrontMatterFormat, bool) {
+	firstLine, _, _ := strings.Cut(content, "\n")
+
+	switch strings.TrimRight(firstLine, "\r") {
+	case "+++":
+		return TOMLFrontMatter, true
+	case "---":
+		return YAMLFrontMatter, true
+	}
+
+	if strings.HasPrefix(firstLine, "{") {
+		return JSONFrontMatter, true
+	}
+
+	return "", false
+}
+
+type FrontMatter struct {
+	Archetype          string
+	Title              string
+	Weight             int
+	HasWeight          bool
+	State              string
+	Slug               string
+	URL                string
+	Aliases            []string
+	Tags               []string
+	Audience           string
+	AudienceImportance string
+	OutsideImportance  string
+	CheckerIgnore      []string
+}
+
+// keys are compared in lowercase, just like Hugo does
+var knownFrontMatterKeys = []string{
+	// content-checker
+	"archetype", "title", "weight", "state", "slug", "url", "aliases", "tags", "audience", "audienceImportance",
+	"outsideImportance", suppressionKey,
+	// hugo
+	"build", "cascade", "categories", "date", "description", "draft", "expiryDate", "headless", "images",
+	"isCJKLanguage", "keywords", "lastmod", "layout", "linkTitle", "markup", "menu", "menus", "outputs", "params",
+	"publishDate", "resources", "series", "sitemap", "summary", "translationKey", "type",
+	// theme
+	"alwaysopen", "collapsibleMenu", "disableMathJax", "disableMermaid", "disableOpenapi", "hidden", "menuPre",
+	"menuPost", "menuTitle", "ordersectionsby",
+}
+
+func decodeFrontMatter(format FrontMatterFormat, header string, positions map[string]Position) (FrontMatter, []Issue) {
+	raw := make(map[string]any)
+
+	var err error
+	switch format {
+	case TOMLFrontMatter:
+		// the header is cut before its last line break, without it errors at the end are reported a line too early
+		_, err = toml.Decode(header+EOL, &raw)
+	case YAMLFrontMatter:
+		err = yaml.Unmarshal([]byte(header), &raw)
+	case JSONFrontMatter:
+		err = json.Unmarshal([]byte(header), &raw)
+	}
+
+	if err != nil {
+		issue := NewIssue(RuleFrontMatterSyntax, "front matter could not be parsed: "+err.Error())
+
+		return FrontMatter{}, []Issue{issue.At(syntaxErrorPosition(format, header, err))}
+	}
+
+	d := frontMatterDecoder{raw: raw, positions: positions}
+
+	_, hasWeight := raw["weight"]
+
+	frontMatter := FrontMatter{
+		Archetype:          d.string("archetype"),
+		Title:              d.string("title"),
+		Weight:             d.int("weight"),
+		HasWeight:          hasWeight,
+		State:              d.string("state"),
+		Slug:               d.string("slug"),
+		URL:                d.string("url"),
+		Aliases:            d.list("aliases"),
+		Tags:               d.list("tags"),
+		Audience:           d.string("audience"),
+		AudienceImportance: d.string("audienceImportance"),
+		OutsideImportance:  d.string("outsideImportance"),
+		CheckerIgnore:      d.list(suppressionKey),
+	}
+
+	return frontMatter, append(d.issues, getUnknownKeyIssues(raw, positions)...)
+}
+
+var regexYAMLErrorLine = regexp.MustCompile(`line (\d+)`)
+
+func syntaxErrorPosition(format FrontMatterFormat, header string, err error) Position {
+	line := 0
+
+	var tomlErr toml.ParseError
+	var jsonErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &tomlErr):
+		line = tomlErr.Position.Line
+	case errors.As(err, &jsonErr):
+		line = positionAt(header, int(min(jsonErr.Offset, int64(len(header))))).Line
+	case format == YAMLFrontMatter:
+		if matches := regexYAMLErrorLine.FindStringSubmatch(err.Error()); len(matches) == 2 {
+			line, _ = strconv.Atoi(matches[1])
+		}
+	}
+
+	if line < 1 {
+		return Position{}
+	}
+
+	return Position{Line: format.firstLine() + line - 1, Column: 1}
+}
+
+type frontMatterDecoder struct {
+	raw       map[string]any
+	positions map[string]Position
+	issues    []Issue
+}
+
+func (d *frontMatterDecoder) typeIssue(key, expected string) {
+	d.issues = append(d.issues, NewIssue(RuleFrontMatterType, fmt.Sprintf("front matter key %s must be %s", key, expected)).At(d.positions[key]))
+}
+
+func (d *frontMatterDecoder) string(key string) string {
+	value, ok := d.raw[key]
+	if !ok {
+		return ""
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		d.typeIssue(key, "a string")
+	}
+
+	return s
+}
+
+func (d *frontMatterDecoder) int(key string) int {
+	switch value := d.raw[key].(type) {
+	case nil:
+		return 0
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		// JSON numbers are always decoded as floats
+		if value == float64(int(value)) {
+			return int(value)
+		}
+	}
+
+	d.typeIssue(key, "an integer")
+
+	return 0
+}
+
+func (d *frontMatterDecoder) list(key string) []string {
+	value, ok := d.raw[key]
+	if !ok {
+		return nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		d.typeIssue(key, "a list of strings")
+
+		return nil
+	}
+
+	var result []string
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			d.typeIssue(key, "a list of strings")
+
+			return nil
+		}
+
+		result = append(result, s)
+	}
+
+	return result
+}
+
+func getUnknownKeyIssues(raw map[string]any, positions map[string]Position) []Issue {
+	known := make(map[string]struct{}, len(knownFrontMatterKeys))
+	for _, key := range knownFrontMatterKeys {
+		known[strings.ToLower(key)] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var issues []Issue
+	for _, key := range keys {
+		if _, ok := known[strings.ToLower(key)]; ok {
+			continue
+		}
+		if _, ok := additionalFrontMatterKeys[strings.ToLower(key)]; ok {
+			continue
+		}
+
+		message := "unknown front matter key: " + key
+		if suggestion := suggestKey(key, knownFrontMatterKeys); suggestion != "" {
+			message += ", did you mean " + suggestion + "?"
+		}
+
+		issues = append(issues, NewIssue(RuleFrontMatterUnknownKey, message).At(positions[key]))
+	}
+
+	return issues
+}
+
+const maxSuggestionDistance = 2
+
+func suggestKey(key string, candidates []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToLower(key), strings.ToLower(candidate))
+		if distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+var errJSONFrontMatterEdit = errors.New("JSON front matter cannot be edited")
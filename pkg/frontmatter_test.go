@@ -0,0 +1,265 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarkdown_FrontMatterFormats(t *testing.T) {
+	const body = `
+Summary
+-------
+
+- foo
+`
+
+	tests := []struct {
+		name          string
+		rawContent    string
+		wantFormat    FrontMatterFormat
+		wantPositions map[string]Position
+		wantSummary   Position
+	}{
+		{
+			name: "toml",
+			rawContent: `+++
+title = "Loops"
+weight = 20
+slug = "loops"
+tags = ["go", "basics"]
++++
+` + body,
+			wantFormat: TOMLFrontMatter,
+			wantPositions: map[string]Position{
+				"title":  {Line: 2, Column: 1},
+				"weight": {Line: 3, Column: 1},
+				"slug":   {Line: 4, Column: 1},
+				"tags":   {Line: 5, Column: 1},
+			},
+			wantSummary: Position{Line: 8, Column: 1},
+		},
+		{
+			name: "yaml",
+			rawContent: `---
+title: Loops
+weight: 20
+slug: "loops"
+tags:
+  - go
+  - basics
+---
+` + body,
+			wantFormat: YAMLFrontMatter,
+			wantPositions: map[string]Position{
+				"title":  {Line: 2, Column: 1},
+				"weight": {Line: 3, Column: 1},
+				"slug":   {Line: 4, Column: 1},
+				"tags":   {Line: 5, Column: 1},
+			},
+			wantSummary: Position{Line: 10, Column: 1},
+		},
+		{
+			name: "json",
+			rawContent: `{
+  "title": "Loops",
+  "weight": 20,
+  "slug": "loops",
+  "tags": ["go", "basics"]
+}
+` + body,
+			wantFormat: JSONFrontMatter,
+			wantPositions: map[string]Position{
+				"title":  {Line: 2, Column: 4},
+				"weight": {Line: 3, Column: 4},
+				"slug":   {Line: 4, Column: 4},
+				"tags":   {Line: 5, Column: 4},
+			},
+			wantSummary: Position{Line: 8, Column: 1},
+		},
+		{
+			name:          "single-line json",
+			rawContent:    `{"title": "Loops", "weight": 20, "slug": "loops", "tags": ["go", "basics"]}` + "\n" + body,
+			wantFormat:    JSONFrontMatter,
+			wantPositions: map[string]Position{},
+			wantSummary:   Position{Line: 3, Column: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got, err := ParseMarkdown(tt.rawContent)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, got.FrontMatterFormat)
+			assert.Equal(t, "Loops", got.Title)
+			assert.Equal(t, "20", got.Weight)
+			assert.Equal(t, "loops", got.Slug)
+			assert.Equal(t, []string{"go", "basics"}, got.Tags)
+			assert.Equal(t, tt.wantPositions, got.Positions)
+			assert.Equal(t, map[string]Position{sectionSummary: tt.wantSummary}, got.SectionPositions)
+		})
+	}
+}
+
+func TestContent_GetIssues_FrontMatterFormat(t *testing.T) {
+	defer ApplyConfig(DefaultConfig())
+
+	config := DefaultConfig()
+	config.FrontMatterFormat = "toml"
+	ApplyConfig(config)
+
+	content, err := ParseMarkdown("---\ntitle: Basics\narchetype: chapter\naudience: all\n---\n\nEpisodes\n--------\n\n- foo\n")
+	require.NoError(t, err)
+
+	// execute
+	issues := content.GetIssues("content/go/basics/_index.md", "go", "basics", "_index.md")
+
+	// verify
+	assert.Equal(t, []Issue{
+		NewIssue(RuleFrontMatterFormat, "front matter format is yaml, expected: toml").At(Position{Line: 1, Column: 1}),
+	}, issues)
+}
+
+func TestParseMarkdown_FrontMatterIssues(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawContent string
+		want       []Issue
+	}{
+		{
+			name:       "toml syntax error",
+			rawContent: "+++\ntitle = \"Loops\"\nweight = 20\ntags = [\"go\"\n+++\n\nfoo\n",
+			want: []Issue{
+				NewIssue(RuleFrontMatterSyntax, `front matter could not be parsed: toml: line 3 (last key "tags"): expected a comma (',') or array terminator (']'), but got end of file`).At(Position{Line: 4, Column: 1}),
+			},
+		},
+		{
+			name:       "yaml syntax error",
+			rawContent: "---\ntitle: Loops\n\tweight: 20\nslug: loops\n---\n\nfoo\n",
+			want: []Issue{
+				NewIssue(RuleFrontMatterSyntax, "front matter could not be parsed: yaml: line 2: found a tab character that violates indentation").At(Position{Line: 3, Column: 1}),
+			},
+		},
+		{
+			name:       "json syntax error",
+			rawContent: "{\n  \"title\": \"Loops\",\n  \"weight\": 20,\n}\n\nfoo\n",
+			want: []Issue{
+				NewIssue(RuleFrontMatterSyntax, "front matter could not be parsed: invalid character '}' looking for beginning of object key string").At(Position{Line: 4, Column: 1}),
+			},
+		},
+		{
+			name:       "wrong types",
+			rawContent: "+++\ntitle = \"Loops\"\nweight = \"20\"\ntags = \"go\"\n+++\n\nfoo\n",
+			want: []Issue{
+				NewIssue(RuleFrontMatterType, "front matter key weight must be an integer").At(Position{Line: 3, Column: 1}),
+				NewIssue(RuleFrontMatterType, "front matter key tags must be a list of strings").At(Position{Line: 4, Column: 1}),
+			},
+		},
+		{
+			name:       "unknown keys",
+			rawContent: "---\ntitle: Loops\naudiance: all\nfoo: bar\nlinktitle: Loops\n---\n\nfoo\n",
+			want: []Issue{
+				NewIssue(RuleFrontMatterUnknownKey, "unknown front matter key: audiance, did you mean audience?").At(Position{Line: 3, Column: 1}),
+				NewIssue(RuleFrontMatterUnknownKey, "unknown front matter key: foo").At(Position{Line: 4, Column: 1}),
+			},
+		},
+		{
+			name: "multi-line arrays, tables and comments",
+			rawContent: `+++
+title = "Loops" # shown in the menu
+weight = 20
+tags = [
+  "go", # the language
+  "basics",
+]
+
+[params]
+foo = "bar"
++++
+
+foo
+`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got, err := ParseMarkdown(tt.rawContent)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.FrontMatterIssues)
+		})
+	}
+}
+
+func TestParseMarkdown_FrontMatterValues(t *testing.T) {
+	rawContent := `+++
+title = "Loops" # shown in the menu
+weight = 20
+tags = [
+  "go", # the language
+  "basics",
+]
+checkerIgnore = ["CC503"]
+url = "/go/loops/"
+aliases = ["/go/basics/loop/", "iterations"]
+
+[params]
+foo = "bar"
++++
+
+foo
+`
+
+	// execute
+	got, err := ParseMarkdown(rawContent)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "Loops", got.Title)
+	assert.Equal(t, "20", got.Weight)
+	assert.Equal(t, []string{"go", "basics"}, got.Tags)
+	assert.Equal(t, "/go/loops/", got.URL)
+	assert.Equal(t, []string{"/go/basics/loop/", "iterations"}, got.Aliases)
+	assert.Equal(t, []Suppression{{Rule: "CC503", Position: Position{Line: 8, Column: 1}}}, got.Suppressions)
+}
+
+func TestContent_GetIssues_FrontMatterSyntax(t *testing.T) {
+	content, err := ParseMarkdown("+++\ntitle = \"Loops\nweight = 20\n+++\n\nfoo\n")
+	require.NoError(t, err)
+
+	// execute
+	issues := content.GetIssues("content/go/basics/20-loops.md", "go", "basics", "20-loops.md")
+
+	// verify
+	require.Len(t, issues, 1)
+	assert.Equal(t, RuleFrontMatterSyntax, issues[0].Rule)
+	assert.Equal(t, Position{Line: 2, Column: 1}, issues[0].Position)
+}
+
+func Test_suggestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "typo", key: "audiance", want: "audience"},
+		{name: "case", key: "Weight", want: "weight"},
+		{name: "missing letter", key: "tite", want: "title"},
+		{name: "too far", key: "foo", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := suggestKey(tt.key, knownFrontMatterKeys)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,7 +7,7 @@ type IndexBody struct {
 	State       State
 }
 
-func (ib *IndexBody) GetIssues(_ State) []string {
+func (ib *IndexBody) GetIssues(_ State) []Issue {
 	return nil
 }
 
@@ -0,0 +1,180 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Rule string
+
+const (
+	RuleFileNameWeight         Rule = "file-name-weight"
+	RuleFileNameSlug           Rule = "file-name-slug"
+	RuleSlugMismatch           Rule = "slug-mismatch"
+	RuleChapterSlug            Rule = "chapter-slug"
+	RuleEmptySections          Rule = "empty-sections"
+	RuleInvalidAudience        Rule = "invalid-audience"
+	RuleImportanceOrder        Rule = "importance-order"
+	RuleOutsideImportance      Rule = "outside-importance"
+	RuleTagUnsorted            Rule = "tag-unsorted"
+	RuleTagCase                Rule = "tag-case"
+	RuleTagSpaces              Rule = "tag-spaces"
+	RuleMainVideoMissing       Rule = "main-video-missing"
+	RuleMainVideoNotMissing    Rule = "main-video-not-missing"
+	RuleStateMismatch          Rule = "state-mismatch"
+	RuleFileUnreadable         Rule = "file-unreadable"
+	RuleFileEmpty              Rule = "file-empty"
+	RuleFrontMatterInvalid     Rule = "front-matter-invalid"
+	RuleFrontMatterFormat      Rule = "front-matter-format"
+	RuleFrontMatterSyntax      Rule = "front-matter-syntax"
+	RuleFrontMatterType        Rule = "front-matter-type"
+	RuleFrontMatterUnknownKey  Rule = "front-matter-unknown-key"
+	RuleSectionOrder           Rule = "section-order"
+	RuleSummaryMissing         Rule = "summary-missing"
+	RuleTopicsMissing          Rule = "topics-missing"
+	RuleTimeMissing            Rule = "time-missing"
+	RuleTimeInvalid            Rule = "time-invalid"
+	RuleTimeMultiple           Rule = "time-multiple"
+	RuleBadgeUnknown           Rule = "badge-unknown"
+	RuleBadgeMissing           Rule = "badge-missing"
+	RuleBadgeUnexpected        Rule = "badge-unexpected"
+	RuleBadgeOrder             Rule = "badge-order"
+	RuleBadgeLength            Rule = "badge-length"
+	RuleYoutubeMissing         Rule = "youtube-missing"
+	RuleYoutubeUnexpected      Rule = "youtube-unexpected"
+	RuleYoutubeMultiple        Rule = "youtube-multiple"
+	RulePageWeight             Rule = "page-weight"
+	RulePageMissing            Rule = "page-missing"
+	RulePageWeightDuplicate    Rule = "page-weight-duplicate"
+	RulePageWeightGap          Rule = "page-weight-gap"
+	RuleChapterMissing         Rule = "chapter-missing"
+	RuleChapterWeightDuplicate Rule = "chapter-weight-duplicate"
+	RuleChapterWeightGap       Rule = "chapter-weight-gap"
+	RuleInternalLinkNotFound   Rule = "internal-link-not-found"
+	RuleInternalLinkAnchor     Rule = "internal-link-anchor"
+	RuleInternalLinkSlash      Rule = "internal-link-slash"
+	RuleExternalLinkStatus     Rule = "external-link-status"
+	RuleFileLinkNotFound       Rule = "file-link-not-found"
+	RuleExternalLinkMoved      Rule = "external-link-moved"
+	RuleExternalRedirectLoop   Rule = "external-redirect-loop"
+	RuleExternalRedirectDomain Rule = "external-redirect-domain"
+	RuleExternalLinkError      Rule = "external-link-error"
+	RuleOrphanPage             Rule = "orphan-page"
+	RuleAssetUnreferenced      Rule = "asset-unreferenced"
+	RuleFileLinkCase           Rule = "file-link-case"
+	RuleImageAltMissing        Rule = "image-alt-missing"
+	RuleReferenceUndefined     Rule = "reference-undefined"
+	RuleRefNotFound            Rule = "ref-not-found"
+	RuleRefAmbiguous           Rule = "ref-ambiguous"
+	RuleAliasCollision         Rule = "alias-collision"
+	RuleSuppressionUnused      Rule = "suppression-unused"
+	RuleSuppressionUnknown     Rule = "suppression-unknown"
+	RuleSuppressionUnsupported Rule = "suppression-unsupported"
+)
+
+// Position is 1-based, the zero value is used for issues concerning the whole file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) IsZero() bool {
+	return p.Line == 0
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+func (p Position) Offset(base Position) Position {
+	if p.IsZero() || base.IsZero() {
+		return p
+	}
+
+	if p.Line == 1 {
+		return Position{Line: base.Line, Column: base.Column + p.Column - 1}
+	}
+
+	return Position{Line: base.Line + p.Line - 1, Column: p.Column}
+}
+
+func positionAt(content string, offset int) Position {
+	before := content[:offset]
+
+	return Position{
+		Line:   strings.Count(before, EOL) + 1,
+		Column: offset - strings.LastIndex(before, EOL),
+	}
+}
+
+type Issue struct {
+	Rule     Rule
+	Severity Severity
+	Message  string
+	Position Position
+}
+
+func NewIssue(rule Rule, message string) Issue {
+	return Issue{
+		Rule:     rule,
+		Severity: rule.Info().Severity,
+		Message:  message,
+	}
+}
+
+func (i Issue) At(position Position) Issue {
+	i.Position = position
+
+	return i
+}
+
+func (i Issue) String() string {
+	return i.Message
+}
+
+type Record struct {
+	FilePath string   `json:"file"`
+	Course   string   `json:"course"`
+	Chapter  string   `json:"chapter"`
+	Page     string   `json:"page"`
+	Code     string   `json:"code"`
+	Rule     Rule     `json:"rule"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+func NewRecord(filePath, course, chapter, page string, issue Issue) Record {
+	return Record{
+		FilePath: filePath,
+		Course:   course,
+		Chapter:  chapter,
+		Page:     page,
+		Code:     issue.Rule.Code(),
+		Rule:     issue.Rule,
+		Message:  issue.Message,
+		Severity: issue.Severity,
+		Line:     issue.Position.Line,
+		Column:   issue.Position.Column,
+	}
+}
+
+func (r Record) Location() string {
+	if r.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d", r.FilePath, r.Line, r.Column)
+	}
+
+	return r.FilePath
+}
+
+func (r Record) String() string {
+	return fmt.Sprintf("%s - %s", r.Location(), r.Message)
+}
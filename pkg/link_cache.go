@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const LinkCacheFileName = ".content-checker/linkcache.json"
+
+type LinkCacheEntry struct {
+	Code      int       `json:"code"`
+	FinalURL  string    `json:"finalUrl,omitempty"`
+	Redirect  int       `json:"redirect,omitempty"`
+	Loop      bool      `json:"loop,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// LinkCache is safe for concurrent use.
+type LinkCache struct {
+	filePath string
+	ttl      time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]LinkCacheEntry
+}
+
+func NewLinkCache(filePath string, ttl time.Duration) *LinkCache {
+	return &LinkCache{
+		filePath: filePath,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]LinkCacheEntry),
+	}
+}
+
+func (c *LinkCache) Load() error {
+	raw, err := os.ReadFile(c.filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("link cache could not be read, err: %w", err)
+	}
+
+	entries := make(map[string]LinkCacheEntry)
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return fmt.Errorf("link cache could not be parsed, err: %w", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = entries
+
+	return nil
+}
+
+func (c *LinkCache) Get(url string) (Result, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok || c.now().Sub(entry.CheckedAt) > c.ttl {
+		return Result{}, false
+	}
+
+	return Result{URL: url, Code: entry.Code, FinalURL: entry.FinalURL, Redirect: entry.Redirect, Loop: entry.Loop}, true
+}
+
+// Set skips results without a status code, e.g. failed connections, as they are usually temporary.
+func (c *LinkCache) Set(result Result) {
+	if result.Code == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[result.URL] = LinkCacheEntry{
+		Code:      result.Code,
+		FinalURL:  result.FinalURL,
+		Redirect:  result.Redirect,
+		Loop:      result.Loop,
+		CheckedAt: c.now(),
+	}
+}
+
+func (c *LinkCache) Save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entries := make(map[string]LinkCacheEntry, len(c.entries))
+	for url, entry := range c.entries {
+		if c.now().Sub(entry.CheckedAt) <= c.ttl {
+			entries[url] = entry
+		}
+	}
+
+	raw, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("link cache could not be encoded, err: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.filePath), 0o755); err != nil {
+		return fmt.Errorf("link cache directory could not be created, err: %w", err)
+	}
+
+	if err := os.WriteFile(c.filePath, append(raw, '\n'), 0o644); err != nil {
+		return fmt.Errorf("link cache could not be written, err: %w", err)
+	}
+
+	return nil
+}
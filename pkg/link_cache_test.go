@@ -0,0 +1,114 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkCache_Get(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	linkCache := NewLinkCache(filepath.Join(t.TempDir(), LinkCacheFileName), time.Hour)
+	linkCache.now = func() time.Time { return now }
+
+	linkCache.Set(Result{URL: "https://example.com/a", Code: 200, FinalURL: "https://example.com/a/"})
+	linkCache.Set(Result{URL: "https://example.com/b", Code: 0})
+
+	tests := []struct {
+		name   string
+		url    string
+		after  time.Duration
+		want   Result
+		wantOK bool
+	}{
+		{
+			name:   "fresh",
+			url:    "https://example.com/a",
+			after:  30 * time.Minute,
+			want:   Result{URL: "https://example.com/a", Code: 200, FinalURL: "https://example.com/a/"},
+			wantOK: true,
+		},
+		{
+			name:   "expired",
+			url:    "https://example.com/a",
+			after:  2 * time.Hour,
+			wantOK: false,
+		},
+		{
+			name:   "failed requests are not cached",
+			url:    "https://example.com/b",
+			wantOK: false,
+		},
+		{
+			name:   "missing",
+			url:    "https://example.com/c",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkCache.now = func() time.Time { return now.Add(tt.after) }
+
+			// execute
+			got, ok := linkCache.Get(tt.url)
+
+			// verify
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLinkCache_SaveLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), LinkCacheFileName)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	linkCache := NewLinkCache(filePath, time.Hour)
+	linkCache.now = func() time.Time { return now.Add(-2 * time.Hour) }
+	linkCache.Set(Result{URL: "https://example.com/old", Code: 200})
+	linkCache.now = func() time.Time { return now }
+	linkCache.Set(Result{URL: "https://example.com/new", Code: 404})
+
+	// execute
+	require.NoError(t, linkCache.Save())
+
+	loaded := NewLinkCache(filePath, time.Hour)
+	loaded.now = func() time.Time { return now }
+	require.NoError(t, loaded.Load())
+
+	// verify
+	assert.Equal(t, map[string]LinkCacheEntry{
+		"https://example.com/new": {Code: 404, CheckedAt: now},
+	}, loaded.entries)
+}
+
+func TestLinkCache_Load(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		linkCache := NewLinkCache(filepath.Join(t.TempDir(), LinkCacheFileName), time.Hour)
+
+		// execute
+		err := linkCache.Load()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Empty(t, linkCache.entries)
+	})
+
+	t.Run("broken file", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "linkcache.json")
+		require.NoError(t, os.WriteFile(filePath, []byte("{"), 0o644))
+
+		linkCache := NewLinkCache(filePath, time.Hour)
+
+		// execute
+		err := linkCache.Load()
+
+		// verify
+		assert.Error(t, err)
+	})
+}
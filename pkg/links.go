@@ -0,0 +1,315 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type LinkKind string
+
+const (
+	LinkKindAnchor     LinkKind = "anchor"
+	LinkKindImage      LinkKind = "image"
+	LinkKindDefinition LinkKind = "definition"
+	LinkKindAutolink   LinkKind = "autolink"
+	// the URL of refs is the reference to resolve
+	LinkKindRef LinkKind = "ref"
+)
+
+type Link struct {
+	Kind     LinkKind
+	URL      string
+	Text     string
+	Position Position
+}
+
+// linkText matches the text of a link, allowing one level of nested brackets, e.g. for images in links
+const linkText = `((?:[^\[\]]|\[[^\[\]]*\])*)`
+
+var (
+	regexInlineLink    = regexp.MustCompile(`(!?)\[` + linkText + `\]\(\s*<?((?:[^()\s<>]|\([^()\s]*\))+)>?(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	regexReferenceLink = regexp.MustCompile(`(!?)\[` + linkText + `\]\[([^\[\]]*)\]`)
+	regexDefinition    = regexp.MustCompile(`^ {0,3}\[([^\[\]]+)\]:\s*<?([^\s>]+)>?(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*$`)
+	regexAutolink      = regexp.MustCompile(`<((?:https?|ftp)://[^\s<>]+)>`)
+	regexHTMLImage     = regexp.MustCompile(`(?i)<img\s[^>]*>`)
+	regexHTMLAttribute = regexp.MustCompile(`(?i)\s(src|alt)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	regexCodeSpan      = regexp.MustCompile("`+[^`]*`+")
+	regexRef           = regexp.MustCompile(`\{\{[<%]\s*(?:rel)?ref\s+(?:path\s*=\s*)?"([^"]*)"[^}]*[>%]\}\}`)
+)
+
+type referenceUsage struct {
+	label    string
+	image    bool
+	text     string
+	position Position
+}
+
+func normalizeLink(link string) string {
+	fragment := ""
+	if index := strings.Index(link, "#"); index >= 0 {
+		link, fragment = link[:index], link[index:]
+	}
+
+	if index := strings.Index(link, "?"); index > 0 {
+		link = link[:index]
+	}
+
+	return link + fragment
+}
+
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+func extractLinks(body string, firstLine int) ([]Link, []Issue) {
+	var links []Link
+	var usages []referenceUsage
+
+	definitions := make(map[string]struct{})
+
+	var code codeBlocks
+	var comments htmlComments
+
+	for i, row := range strings.Split(body, EOL) {
+		line := firstLine + i
+
+		if !comments.open && code.isCode(row) {
+			continue
+		}
+
+		// code spans are blanked out, keeping the columns of the rest of the row
+		row = regexCodeSpan.ReplaceAllStringFunc(row, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+		row = comments.blank(row)
+
+		if matches := regexDefinition.FindStringSubmatchIndex(row); matches != nil {
+			label := row[matches[2]:matches[3]]
+			definitions[normalizeLabel(label)] = struct{}{}
+
+			links = append(links, Link{
+				Kind:     LinkKindDefinition,
+				URL:      normalizeLink(row[matches[4]:matches[5]]),
+				Text:     label,
+				Position: Position{Line: line, Column: matches[4] + 1},
+			})
+
+			continue
+		}
+
+		links = append(links, extractInlineLinks(row, 0, line)...)
+
+		for _, matches := range regexReferenceLink.FindAllStringSubmatchIndex(row, -1) {
+			// index expressions like matrix[i][j] in prose are not links
+			if matches[0] > 0 && isIdentifierByte(row[matches[0]-1]) {
+				continue
+			}
+
+			text := row[matches[4]:matches[5]]
+			label := row[matches[6]:matches[7]]
+			if label == "" {
+				// collapsed reference, [text][]
+				label = text
+			}
+
+			usages = append(usages, referenceUsage{
+				label:    label,
+				image:    matches[3] > matches[2],
+				text:     text,
+				position: Position{Line: line, Column: matches[0] + 1},
+			})
+		}
+
+		for _, matches := range regexAutolink.FindAllStringSubmatchIndex(row, -1) {
+			// the destination of inline links may be wrapped in angle brackets too, [text](<url>)
+			if matches[0] > 0 && row[matches[0]-1] == '(' {
+				continue
+			}
+
+			links = append(links, Link{
+				Kind:     LinkKindAutolink,
+				URL:      normalizeLink(row[matches[2]:matches[3]]),
+				Position: Position{Line: line, Column: matches[2] + 1},
+			})
+		}
+
+		for _, matches := range regexRef.FindAllStringSubmatchIndex(row, -1) {
+			links = append(links, Link{
+				Kind:     LinkKindRef,
+				URL:      row[matches[2]:matches[3]],
+				Position: Position{Line: line, Column: matches[2] + 1},
+			})
+		}
+
+		for _, tag := range regexHTMLImage.FindAllStringIndex(row, -1) {
+			if link, ok := extractHTMLImage(row[tag[0]:tag[1]], tag[0], line); ok {
+				links = append(links, link)
+			}
+		}
+	}
+
+	var issues []Issue
+
+	for _, link := range links {
+		if link.Kind == LinkKindImage && strings.TrimSpace(link.Text) == "" {
+			issues = append(issues, NewIssue(RuleImageAltMissing, fmt.Sprintf("image has no alt text: %s", link.URL)).At(link.Position))
+		}
+	}
+
+	for _, usage := range usages {
+		if _, ok := definitions[normalizeLabel(usage.label)]; !ok {
+			issues = append(issues, NewIssue(RuleReferenceUndefined, fmt.Sprintf("reference link has no definition: [%s]", usage.label)).At(usage.position))
+
+			continue
+		}
+
+		if usage.image && strings.TrimSpace(usage.text) == "" {
+			issues = append(issues, NewIssue(RuleImageAltMissing, fmt.Sprintf("image has no alt text: [%s]", usage.label)).At(usage.position))
+		}
+	}
+
+	return links, issues
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+var (
+	regexFence    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	regexListItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s`)
+)
+
+type codeBlocks struct {
+	fence    string
+	indented bool
+	inList   bool
+	// prevText is set if the previous row was not blank, so that the body may start with an indented code block
+	prevText bool
+}
+
+// isCode only starts indented code blocks after a blank row outside of lists, as indented rows continue paragraphs and
+// list items otherwise.
+func (c *codeBlocks) isCode(row string) bool {
+	blank := strings.TrimSpace(row) == ""
+	defer func() { c.prevText = !blank }()
+
+	if c.fence != "" {
+		if matches := regexFence.FindStringSubmatch(row); matches != nil && strings.HasPrefix(matches[1], c.fence) &&
+			strings.TrimSpace(row[len(matches[0]):]) == "" {
+			c.fence = ""
+		}
+
+		return true
+	}
+
+	if matches := regexFence.FindStringSubmatch(row); matches != nil {
+		c.fence = matches[1]
+		c.indented = false
+
+		return true
+	}
+
+	if blank {
+		return c.indented
+	}
+
+	if strings.HasPrefix(row, "    ") || strings.HasPrefix(row, "\t") {
+		c.indented = c.indented || (!c.prevText && !c.inList)
+
+		return c.indented
+	}
+
+	c.indented = false
+	c.inList = regexListItem.MatchString(row) || (c.inList && c.prevText)
+
+	return false
+}
+
+// htmlComments tracks comments spanning multiple rows, fenced code inside of them is not code.
+type htmlComments struct {
+	open bool
+}
+
+func (c *htmlComments) blank(row string) string {
+	var sb strings.Builder
+
+	for row != "" {
+		if !c.open {
+			start := strings.Index(row, "<!--")
+			if start == -1 {
+				sb.WriteString(row)
+
+				break
+			}
+
+			sb.WriteString(row[:start] + "    ")
+			row = row[start+4:]
+			c.open = true
+		}
+
+		end := strings.Index(row, "-->")
+		if end == -1 {
+			sb.WriteString(strings.Repeat(" ", len(row)))
+
+			break
+		}
+
+		sb.WriteString(strings.Repeat(" ", end+3))
+		row = row[end+3:]
+		c.open = false
+	}
+
+	return sb.String()
+}
+
+// extractInlineLinks searches the text of links as well, as it may contain an image.
+func extractInlineLinks(row string, offset, line int) []Link {
+	var links []Link
+
+	for _, matches := range regexInlineLink.FindAllStringSubmatchIndex(row, -1) {
+		kind := LinkKindAnchor
+		if matches[3] > matches[2] {
+			kind = LinkKindImage
+		}
+
+		text := row[matches[4]:matches[5]]
+
+		links = append(links, Link{
+			Kind:     kind,
+			URL:      normalizeLink(row[matches[6]:matches[7]]),
+			Text:     text,
+			Position: Position{Line: line, Column: offset + matches[6] + 1},
+		})
+
+		if kind == LinkKindAnchor {
+			links = append(links, extractInlineLinks(text, offset+matches[4], line)...)
+		}
+	}
+
+	return links
+}
+
+func extractHTMLImage(tag string, offset, line int) (Link, bool) {
+	link := Link{Kind: LinkKindImage}
+	found := false
+
+	for _, matches := range regexHTMLAttribute.FindAllStringSubmatchIndex(tag, -1) {
+		start, end := matches[4], matches[5]
+		if start < 0 {
+			start, end = matches[6], matches[7]
+		}
+
+		switch strings.ToLower(tag[matches[2]:matches[3]]) {
+		case "src":
+			link.URL = normalizeLink(tag[start:end])
+			link.Position = Position{Line: line, Column: offset + start + 1}
+			found = true
+		case "alt":
+			link.Text = tag[start:end]
+		}
+	}
+
+	return link, found
+}
@@ -0,0 +1,141 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_extractLinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantLinks  []Link
+		wantIssues []Issue
+	}{
+		{
+			name: "inline link at the end of the line",
+			body: "See [the docs](/a1/docs/?tab=1#setup)",
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "/a1/docs/#setup", Text: "the docs", Position: Position{Line: 3, Column: 16}},
+			},
+		},
+		{
+			name: "link with title",
+			body: `[Go](https://go.dev/ "The Go website") and [Hugo](<https://gohugo.io/>)`,
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "https://go.dev/", Text: "Go", Position: Position{Line: 3, Column: 6}},
+				{Kind: LinkKindAnchor, URL: "https://gohugo.io/", Text: "Hugo", Position: Position{Line: 3, Column: 52}},
+			},
+		},
+		{
+			name: "images",
+			body: "![Diagram](/images/diagram.png)\n![](/images/empty.png)",
+			wantLinks: []Link{
+				{Kind: LinkKindImage, URL: "/images/diagram.png", Text: "Diagram", Position: Position{Line: 3, Column: 12}},
+				{Kind: LinkKindImage, URL: "/images/empty.png", Position: Position{Line: 4, Column: 5}},
+			},
+			wantIssues: []Issue{
+				NewIssue(RuleImageAltMissing, "image has no alt text: /images/empty.png").At(Position{Line: 4, Column: 5}),
+			},
+		},
+		{
+			name: "image in link",
+			body: "[![Logo](/logo.png)](https://example.com/)",
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "https://example.com/", Text: "![Logo](/logo.png)", Position: Position{Line: 3, Column: 22}},
+				{Kind: LinkKindImage, URL: "/logo.png", Text: "Logo", Position: Position{Line: 3, Column: 10}},
+			},
+		},
+		{
+			name: "html images",
+			body: `<img src="/images/a.png" alt="A"> <IMG alt='' src='/images/b.png'>`,
+			wantLinks: []Link{
+				{Kind: LinkKindImage, URL: "/images/a.png", Text: "A", Position: Position{Line: 3, Column: 11}},
+				{Kind: LinkKindImage, URL: "/images/b.png", Position: Position{Line: 3, Column: 52}},
+			},
+			wantIssues: []Issue{
+				NewIssue(RuleImageAltMissing, "image has no alt text: /images/b.png").At(Position{Line: 3, Column: 52}),
+			},
+		},
+		{
+			name: "reference links",
+			body: "Read [the spec][Spec], [Go][] and [Hugo][hugo]\n\n[spec]: https://spec.commonmark.org/ \"CommonMark\"\n[Go]: <https://go.dev/>",
+			wantLinks: []Link{
+				{Kind: LinkKindDefinition, URL: "https://spec.commonmark.org/", Text: "spec", Position: Position{Line: 5, Column: 9}},
+				{Kind: LinkKindDefinition, URL: "https://go.dev/", Text: "Go", Position: Position{Line: 6, Column: 8}},
+			},
+			wantIssues: []Issue{
+				NewIssue(RuleReferenceUndefined, "reference link has no definition: [hugo]").At(Position{Line: 3, Column: 35}),
+			},
+		},
+		{
+			name: "reference image without alt text",
+			body: "![][logo]\n\n[logo]: /logo.png",
+			wantLinks: []Link{
+				{Kind: LinkKindDefinition, URL: "/logo.png", Text: "logo", Position: Position{Line: 5, Column: 9}},
+			},
+			wantIssues: []Issue{
+				NewIssue(RuleImageAltMissing, "image has no alt text: [logo]").At(Position{Line: 3, Column: 1}),
+			},
+		},
+		{
+			name: "autolinks",
+			body: "Visit <https://example.com/page?utm=1> or <user@example.com>",
+			wantLinks: []Link{
+				{Kind: LinkKindAutolink, URL: "https://example.com/page", Position: Position{Line: 3, Column: 8}},
+			},
+		},
+		{
+			name: "ref shortcodes",
+			body: `See [loops]({{< ref "20-loops.md#summary" >}}) and {{% relref path="/go/basics" %}}`,
+			wantLinks: []Link{
+				{Kind: LinkKindRef, URL: "20-loops.md#summary", Position: Position{Line: 3, Column: 22}},
+				{Kind: LinkKindRef, URL: "/go/basics", Position: Position{Line: 3, Column: 69}},
+			},
+		},
+		{
+			name: "code is skipped",
+			body: "Use `[text](url)` for links\n\n```markdown\n[foo](/bar/)\n![](/baz.png)\n```",
+		},
+		{
+			name: "tilde fences and indented code are skipped",
+			body: "~~~markdown\n[foo](/bar/)\n```\n[baz][qux]\n~~~\n\n    [foo](/indented/)\n\n    ![](/indented.png)\n\n[after](/after/)",
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "/after/", Text: "after", Position: Position{Line: 13, Column: 9}},
+			},
+		},
+		{
+			name: "indented list continuation",
+			body: "- item\n\n    see [foo](/foo/)",
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "/foo/", Text: "foo", Position: Position{Line: 5, Column: 15}},
+			},
+		},
+		{
+			name: "html comments are skipped",
+			body: "<!-- [old](/old/) --> [new](/new/)\n\n<!--\n```\n![](/old.png)\n-->\n\n[after](/after/)",
+			wantLinks: []Link{
+				{Kind: LinkKindAnchor, URL: "/new/", Text: "new", Position: Position{Line: 3, Column: 29}},
+				{Kind: LinkKindAnchor, URL: "/after/", Text: "after", Position: Position{Line: 10, Column: 9}},
+			},
+		},
+		{
+			name: "index expressions",
+			body: "Use matrix[i][j] or `grid[x][y]`, not [the grid][grid]",
+			wantIssues: []Issue{
+				NewIssue(RuleReferenceUndefined, "reference link has no definition: [grid]").At(Position{Line: 3, Column: 39}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			gotLinks, gotIssues := extractLinks(tt.body, 3)
+
+			// verify
+			assert.Equal(t, tt.wantLinks, gotLinks)
+			assert.Equal(t, tt.wantIssues, gotIssues)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -31,7 +32,8 @@ const (
 	sectionAdditionalChallenges  = "additional challenges"
 )
 
-const (
+// video length limits in minutes, can be overridden by the configuration
+var (
 	maxNonFullCourseLength = 119
 	maxExtraLength         = 60
 	minDeepDiveLength      = 30
@@ -47,17 +49,19 @@ func ParseMarkdown(rawContent string) (Content, error) {
 	// Convert DOS/Windows line endings (\r\n) into Linux/Unix line endings
 	strContent := strings.Replace(rawContent, "\r\n", EOL, -1)
 
-	header, body, err := splitMarkdown(strContent)
+	format, header, body, bodyLine, err := splitMarkdown(strContent)
 	if err != nil {
 		return Content{}, fmt.Errorf("markdown header could not be extracted, err: %w", err)
 	}
 
-	sections := extractSections(body)
-	headers := getHeaderValues(header)
-	tags := getTags(headers, nil)
+	positions := getHeaderPositions(format, header)
+	frontMatter, frontMatterIssues := decodeFrontMatter(format, header, positions)
+
+	sections := extractSections(body, bodyLine)
+	tags := frontMatter.Tags
 
 	var content Content
-	if getValueWithDefault(headers, "archetype", "") == "chapter" {
+	if frontMatter.Archetype == "chapter" {
 		content.Body = sectionsToIndexBody(sections)
 	} else if sections.HasNonEmpty(sectionDescription) {
 		content.Body = sectionsToPracticeBody(sections)
@@ -65,104 +69,108 @@ func ParseMarkdown(rawContent string) (Content, error) {
 		content.Body = sectionsToDefaultBody(sections, tags)
 	}
 
-	content.Slug = getValueWithDefault(headers, "slug", "")
-	content.Weight = getValueWithDefault(headers, "weight", "")
-	content.Title = getValueWithDefault(headers, "title", "")
-	content.State = State(getValueWithDefault(headers, "state", ""))
-	content.Audience = Audience(getValueWithDefault(headers, "audience", ""))
-	content.Importance = Importance(getValueWithDefault(headers, "audienceImportance", ""))
-	content.OutsideImportance = Importance(getValueWithDefault(headers, "outsideImportance", ""))
+	content.FrontMatterFormat = format
+	content.Slug = frontMatter.Slug
+	content.URL = frontMatter.URL
+	content.Aliases = frontMatter.Aliases
+	if frontMatter.HasWeight {
+		content.Weight = strconv.Itoa(frontMatter.Weight)
+	}
+	content.Title = frontMatter.Title
+	content.State = State(frontMatter.State)
+	content.Audience = Audience(frontMatter.Audience)
+	content.Importance = Importance(frontMatter.AudienceImportance)
+	content.OutsideImportance = Importance(frontMatter.OutsideImportance)
 	content.Tags = tags
+	content.FrontMatterIssues = frontMatterIssues
 	content.EmptySections = sections.EmptyButPresent(sectionRoot)
-	content.Links = getLinks(rawContent)
+	content.Links, content.LinkIssues = extractLinks(body, bodyLine)
+	content.Anchors = sections.Anchors()
+	content.Positions = positions
+	content.SectionPositions = sections.Positions()
+	content.Suppressions = getSuppressions(strContent, frontMatter.CheckerIgnore, content.Positions, sections, strings.Count(strContent, EOL)+1)
 
 	return content, nil
 }
 
-func splitMarkdown(in string) (string, string, error) {
+func splitMarkdown(in string) (FrontMatterFormat, string, string, int, error) {
 	if len(in) < 4 {
-		return "", "", errors.New("markdown too short")
+		return "", "", "", 0, errors.New("markdown too short")
 	}
 
-	// Handle TOML front matter
-	if in[:4] == "+++\n" {
-		if idx := strings.Index(in[4:], "\n+++"); idx != -1 {
-			return in[4 : idx+4], strings.Trim(in[idx+8:], "\n+"), nil
-		}
+	format, ok := getFrontMatterFormat(in)
+	if !ok {
+		return "", "", "", 0, errors.New("could not split markdown")
 	}
 
-	return "", "", errors.New("could not split markdown")
-}
-
-var regexHeader = regexp.MustCompile(`^(\S+)\s*=\s*(.*)$`)
-
-func getHeaderValues(header string) map[string]string {
-	values := make(map[string]string)
+	// Handle JSON front matter, which ends with the closing brace of the object
+	if format == JSONFrontMatter {
+		end := -1
 
-	for _, row := range strings.Split(header, "\n") {
-		matches := regexHeader.FindStringSubmatch(row)
-
-		if len(matches) != 3 {
-			continue
+		// invalid objects are cut at the first closing brace on its own line, so that their errors can be reported
+		decoder := json.NewDecoder(strings.NewReader(in))
+		if err := decoder.Decode(&json.RawMessage{}); err == nil {
+			end = int(decoder.InputOffset())
+		} else if idx := strings.Index(in, "\n}"); idx != -1 {
+			end = idx + 2
 		}
 
-		values[matches[1]] = strings.Trim(matches[2], `'"`)
-	}
+		if end == -1 {
+			return "", "", "", 0, errors.New("could not split markdown")
+		}
 
-	return values
-}
+		rest := in[end:]
+		bodyStart := end + len(rest) - len(strings.TrimLeft(rest, "\n"))
 
-func getTags(values map[string]string, defaultValue []string) []string {
-	tagsRaw, ok := values["tags"]
-	if !ok {
-		return defaultValue
+		return format, in[:end], strings.Trim(rest, "\n"), positionAt(in, bodyStart).Line, nil
 	}
 
-	var tags []string
-
-	for _, part := range strings.Split(strings.Trim(tagsRaw, `[]`), ",") {
-		tags = append(tags, strings.Trim(part, ` '"`))
+	// Handle TOML and YAML front matter, only TOML delimiters are trimmed from the body as YAML ones could be lists
+	delimiter := format.delimiter()
+	cutset := "\n"
+	if format == TOMLFrontMatter {
+		cutset = "\n+"
 	}
 
-	return tags
-}
+	if idx := strings.Index(in[4:], "\n"+delimiter); idx != -1 {
+		rest := in[idx+8:]
+		bodyStart := idx + 8 + len(rest) - len(strings.TrimLeft(rest, cutset))
 
-func getValueWithDefault(values map[string]string, key, defaultValue string) string {
-	if value, ok := values[key]; ok {
-		return value
+		return format, in[4 : idx+4], strings.Trim(rest, cutset), positionAt(in, bodyStart).Line, nil
 	}
 
-	return defaultValue
+	return "", "", "", 0, errors.New("could not split markdown")
 }
 
-var linkRegex = regexp.MustCompile(`\[(.*?)\]\((.*?)\)[\. ]`)
+var regexHeader = regexp.MustCompile(`^(\S+)\s*=\s*(.*)$`)
 
-func getLinks(body string) map[string]string {
-	links := make(map[string]string)
+// getHeaderPositions keeps the first position of keys found more than once, e.g. in nested JSON objects.
+func getHeaderPositions(format FrontMatterFormat, header string) map[string]Position {
+	positions := make(map[string]Position)
+	regex := format.keyRegex()
 
-	for i, row := range strings.Split(body, EOL) {
-		finds := linkRegex.FindAllStringSubmatchIndex(row+" ", -1)
-		for _, found := range finds {
-			index := fmt.Sprintf("%d:%d", i+1, found[4])
-			link := row[found[4]:found[5]]
-			link = strings.TrimRight(link, ")")
-			if strings.Index(link, "?") > 0 {
-				link = link[:strings.Index(link, "?")]
-			}
-			if strings.Index(link, "#") > 0 {
-				link = link[:strings.Index(link, "#")]
-			}
+	for i, row := range strings.Split(header, "\n") {
+		matches := regex.FindStringSubmatchIndex(row)
+
+		if len(matches) != 6 {
+			continue
+		}
 
-			links[index] = link
+		key := row[matches[2]:matches[3]]
+		if _, ok := positions[key]; !ok {
+			positions[key] = Position{Line: format.firstLine() + i, Column: matches[2] + 1}
 		}
 	}
 
-	return links
+	return positions
 }
 
 type Section struct {
 	Title   string
 	Content string
+	// the root section starts at the start of the body
+	Position        Position
+	ContentPosition Position
 }
 
 type Sections []Section
@@ -199,6 +207,45 @@ func (s Sections) Get(title string) string {
 	return ""
 }
 
+func (s Sections) GetContentPosition(title string) Position {
+	for _, section := range s {
+		if section.Title == title {
+			return section.ContentPosition
+		}
+	}
+
+	return Position{}
+}
+
+func (s Sections) Positions() map[string]Position {
+	positions := make(map[string]Position, len(s))
+	for _, section := range s {
+		if _, ok := positions[section.Title]; !ok {
+			positions[section.Title] = section.Position
+		}
+	}
+
+	return positions
+}
+
+func (s Sections) LinesAround(line, lineCount int) (int, int) {
+	fromLine, toLine := 1, lineCount
+
+	for _, section := range s {
+		if section.Position.Line <= line {
+			fromLine = section.Position.Line
+
+			continue
+		}
+
+		toLine = section.Position.Line - 1
+
+		break
+	}
+
+	return fromLine, toLine
+}
+
 func (s Sections) Titles() []string {
 	keys := make([]string, 0, len(s))
 	for _, section := range s {
@@ -208,19 +255,33 @@ func (s Sections) Titles() []string {
 	return keys
 }
 
-func extractSections(body string) Sections {
+func extractSections(body string, firstLine int) Sections {
 	var sections Sections
 
 	currentSection := "root"
 	sectionStart := 0
-
+	sectionPosition := Position{Line: firstLine, Column: 1}
 	rows := strings.Split(body, EOL)
+
+	newSection := func(end int) Section {
+		raw := strings.Join(rows[sectionStart:end], EOL)
+		content := strings.Trim(raw, " \t\n")
+		leading := raw[:len(raw)-len(strings.TrimLeft(raw, " \t\n"))]
+
+		return Section{
+			Title:           currentSection,
+			Content:         content,
+			Position:        sectionPosition,
+			ContentPosition: positionAt(leading+" ", len(leading)).Offset(Position{Line: firstLine + sectionStart, Column: 1}),
+		}
+	}
+
 	for i, row := range rows {
 		if len(row) >= 3 && row[:3] == "## " {
-			content := strings.Trim(strings.Join(rows[sectionStart:i], EOL), " \t\n")
-			sections = append(sections, Section{Title: currentSection, Content: content})
+			sections = append(sections, newSection(i))
 
 			sectionStart = i + 1
+			sectionPosition = Position{Line: firstLine + i, Column: 1}
 
 			currentSection = strings.ToLower(strings.Trim(row[3:], " \t"))
 
@@ -233,10 +294,10 @@ func extractSections(body string) Sections {
 				continue
 			}
 
-			content := strings.Trim(strings.Join(rows[sectionStart:i-1], EOL), " \t\n")
-			sections = append(sections, Section{Title: currentSection, Content: content})
+			sections = append(sections, newSection(i-1))
 
 			sectionStart = i + 1
+			sectionPosition = Position{Line: firstLine + i - 1, Column: 1}
 
 			currentSection = strings.ToLower(strings.Trim(rows[i-1], " \t"))
 
@@ -245,8 +306,7 @@ func extractSections(body string) Sections {
 	}
 
 	if currentSection != "root" {
-		content := strings.Trim(strings.Join(rows[sectionStart:], EOL), " \t\n")
-		sections = append(sections, Section{Title: currentSection, Content: content})
+		sections = append(sections, newSection(len(rows)))
 	}
 
 	// Remove the root section if it's empty
@@ -303,44 +363,65 @@ func ExtractVideos(content string, noBadgeOkay bool) Videos {
 		return nil
 	}
 
-	sections := regexSubHeader.Split("\n"+content, -1)
+	// a new line is prepended so that a sub header on the first line is also found
+	extended := "\n" + content
 
-	relatedVideos := make(Videos, 0, len(sections))
-	for _, section := range sections {
+	var starts, ends []int
+	start := 0
+	for _, loc := range regexSubHeader.FindAllStringIndex(extended, -1) {
+		starts, ends = append(starts, start), append(ends, loc[0])
+		start = loc[1]
+	}
+	starts, ends = append(starts, start), append(ends, len(extended))
+
+	relatedVideos := make(Videos, 0, len(starts))
+	for i := range starts {
+		section := extended[starts[i]:ends[i]]
 		if strings.TrimSpace(section) == "" {
 			continue
 		}
 
+		// offsets in content are one less than in extended, except for the prepended new line itself
+		sectionStart := starts[i] - 1
+		if sectionStart < 0 {
+			section, sectionStart = section[1:], 0
+		}
+
 		relatedVideo := extractVideo(section, noBadgeOkay)
 
 		if relatedVideo.Valid {
-			relatedVideos = append(relatedVideos, relatedVideo)
+			relatedVideos = append(relatedVideos, relatedVideo.offset(positionAt(content, sectionStart)))
 		}
 	}
 
 	return relatedVideos
 }
 
+func startPosition(content string) Position {
+	return positionAt(content, len(content)-len(strings.TrimLeft(content, " \t\n")))
+}
+
 var regexTime = regexp.MustCompile(`{{<\s*time\s+(\d+)\s*>}}`)
 
-func extractTime(content string) (int, []string) {
+func extractTime(content string) (int, []Issue) {
 	var (
-		issues  []string
+		issues  []Issue
 		minutes int
 		err     error
 	)
 
-	timeMatches := regexTime.FindAllStringSubmatch(content, -1)
+	timeMatches := regexTime.FindAllStringSubmatchIndex(content, -1)
 	if len(timeMatches) == 0 {
-		issues = append(issues, "missing time shortcode")
+		issues = append(issues, NewIssue(RuleTimeMissing, "missing time shortcode").At(startPosition(content)))
 	} else {
-		minutes, err = strconv.Atoi(timeMatches[0][1])
+		rawMinutes := content[timeMatches[0][2]:timeMatches[0][3]]
+		minutes, err = strconv.Atoi(rawMinutes)
 		if err != nil {
-			issues = append(issues, fmt.Sprintf("failed to parse duration: %s", timeMatches[0][1]))
+			issues = append(issues, NewIssue(RuleTimeInvalid, fmt.Sprintf("failed to parse duration: %s", rawMinutes)).At(positionAt(content, timeMatches[0][0])))
 		}
 	}
 	if len(timeMatches) > 1 {
-		issues = append(issues, "multiple time shortcodes found")
+		issues = append(issues, NewIssue(RuleTimeMultiple, "multiple time shortcodes found").At(positionAt(content, timeMatches[1][0])))
 	}
 
 	return minutes, issues
@@ -348,31 +429,39 @@ func extractTime(content string) (int, []string) {
 
 var regexBadge = regexp.MustCompile(`{{<\s*badge-(\S*)\s*>}}`)
 
-func extractBadges(content string, noBadgeOkay bool) (Badges, bool, []string) {
+func extractBadges(content string, noBadgeOkay bool) (Badges, bool, []Issue) {
 	var (
-		badges = Badges{}
-		issues []string
+		badges    = Badges{}
+		positions []Position
+		issues    []Issue
 	)
 
 	noEmbed := false
-	badgeMatches := regexBadge.FindAllStringSubmatch(content, -1)
+	badgeMatches := regexBadge.FindAllStringSubmatchIndex(content, -1)
 
 	for _, match := range badgeMatches {
-		switch badge := Badge(match[1]); badge {
+		position := positionAt(content, match[0])
+
+		switch badge := Badge(content[match[2]:match[3]]); badge {
 		case Unchecked, Alternative, Extra, Fun, Hint, MustSee, Summary, DeepDive, FullCourse:
 			badges = append(badges, badge)
+			positions = append(positions, position)
 		case NoEmbed:
 			noEmbed = true
 		case Audio, Easy, Medium, Hard:
 			continue
 		default:
-			issues = append(issues, fmt.Sprintf("Unknown badge: '%s'", badge))
+			if _, ok := additionalBadges[badge]; ok {
+				continue
+			}
+
+			issues = append(issues, NewIssue(RuleBadgeUnknown, fmt.Sprintf("Unknown badge: '%s'", badge)).At(position))
 		}
 	}
 
 	if len(badges) == 0 {
 		if !noBadgeOkay {
-			issues = append(issues, "missing badge shortcode")
+			issues = append(issues, NewIssue(RuleBadgeMissing, "missing badge shortcode").At(startPosition(content)))
 		}
 
 		return Badges{}, noEmbed, issues
@@ -380,13 +469,13 @@ func extractBadges(content string, noBadgeOkay bool) (Badges, bool, []string) {
 
 	levelFound := NoEmbed
 
-	for _, badge := range badges {
+	for i, badge := range badges {
 		if badge == Unchecked || badge == Audio || badge == NoEmbed {
 			continue
 		}
 
 		if levelFound != NoEmbed {
-			issues = append(issues, "unexpected badge shortcode found: "+string(badge))
+			issues = append(issues, NewIssue(RuleBadgeUnexpected, "unexpected badge shortcode found: "+string(badge)).At(positions[i]))
 		}
 
 		levelFound = badge
@@ -395,22 +484,22 @@ func extractBadges(content string, noBadgeOkay bool) (Badges, bool, []string) {
 	return badges, noEmbed, issues
 }
 
-func extractYoutube(content string, noEmbed bool) (int, []string) {
-	var issues []string
+func extractYoutube(content string, noEmbed bool) (int, []Issue) {
+	var issues []Issue
 
-	youtubeMatches := regexYoutube.FindAllStringSubmatch(content, -1)
+	youtubeMatches := regexYoutube.FindAllStringIndex(content, -1)
 
 	switch len(youtubeMatches) {
 	case 0:
 		if !noEmbed {
-			issues = append(issues, "missing youtube shortcode")
+			issues = append(issues, NewIssue(RuleYoutubeMissing, "missing youtube shortcode").At(startPosition(content)))
 		}
 	case 1:
 		if noEmbed {
-			issues = append(issues, "unexpected youtube shortcode together with no-embed badge")
+			issues = append(issues, NewIssue(RuleYoutubeUnexpected, "unexpected youtube shortcode together with no-embed badge").At(positionAt(content, youtubeMatches[0][0])))
 		}
 	default:
-		issues = append(issues, "multiple youtube shortcodes found")
+		issues = append(issues, NewIssue(RuleYoutubeMultiple, "multiple youtube shortcodes found").At(positionAt(content, youtubeMatches[1][0])))
 	}
 
 	return len(youtubeMatches), issues
@@ -418,7 +507,7 @@ func extractYoutube(content string, noEmbed bool) (int, []string) {
 
 func extractVideo(content string, noBadgeOkay bool) Video {
 	var (
-		issues  []string
+		issues  []Issue
 		minutes int
 	)
 
@@ -436,15 +525,20 @@ func extractVideo(content string, noBadgeOkay bool) Video {
 	}
 
 	if minutes > 0 && len(badges) > 0 && strings.Index(content, "badge") < strings.Index(content, "time") {
-		issues = append(issues, "badge should be placed after time")
+		issues = append(issues, NewIssue(RuleBadgeOrder, "badge should be placed after time").At(positionAt(content, strings.Index(content, "badge"))))
+	}
+
+	timePosition := startPosition(content)
+	if loc := regexTime.FindStringIndex(content); loc != nil {
+		timePosition = positionAt(content, loc[0])
 	}
 
 	if minutes >= maxNonFullCourseLength && !badges.Has(FullCourse, Fun) {
-		issues = append(issues, "badges should have full-course, but do not. badges: "+badges.String())
+		issues = append(issues, NewIssue(RuleBadgeLength, "badges should have full-course, but do not. badges: "+badges.String()).At(timePosition))
 	} else if minutes > maxExtraLength && badges.Has(Extra) {
-		issues = append(issues, "badges should have deep-dive, but do not. badges: "+badges.String())
+		issues = append(issues, NewIssue(RuleBadgeLength, "badges should have deep-dive, but do not. badges: "+badges.String()).At(timePosition))
 	} else if minutes < minDeepDiveLength && badges.Has(DeepDive) {
-		issues = append(issues, "badges should have extra, but do not. badges: "+badges.String())
+		issues = append(issues, NewIssue(RuleBadgeLength, "badges should have extra, but do not. badges: "+badges.String()).At(timePosition))
 	}
 
 	return Video{
@@ -469,7 +563,8 @@ func sectionsToDefaultBody(sections Sections, tags []string) DefaultBody {
 	hasExercises := sections.HasNonEmpty(sectionExercises)
 
 	main := ExtractMain(sections.Get(sectionMainVideo))
-	relatedVideos := ExtractVideos(sections.Get(sectionRelatedVideos), false)
+	main.Videos = main.Videos.offset(sections.GetContentPosition(sectionMainVideo))
+	relatedVideos := ExtractVideos(sections.Get(sectionRelatedVideos), false).offset(sections.GetContentPosition(sectionRelatedVideos))
 
 	if hasExercises && strings.TrimSpace(sections.Get(sectionExercises)) == "" {
 		hasExercises = false
@@ -504,6 +599,7 @@ func sectionsToDefaultBody(sections Sections, tags []string) DefaultBody {
 		SlugForced:         isSlugForced,
 		Project:            isProject,
 		SectionTitles:      sections.Titles(),
+		SectionPositions:   sections.Positions(),
 	}
 }
 
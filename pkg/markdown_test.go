@@ -35,15 +35,21 @@ title = "Prepare"
 +++`,
 			},
 			want: Content{
-				Title: "Prepare",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
 						Videos: nil,
 					},
-					SectionTitles: []string{},
+					SectionTitles:    []string{},
+					SectionPositions: map[string]Position{},
 				},
-				Links: map[string]string{},
+				Anchors: map[string]struct{}{},
+				Positions: map[string]Position{
+					"title": {Line: 2, Column: 1},
+				},
+				SectionPositions: map[string]Position{},
 			},
 		},
 		{
@@ -54,18 +60,24 @@ state = "incomplete"
 +++`,
 			},
 			want: Content{
-				Title:  "",
-				State:  Incomplete,
-				Weight: "",
-				Slug:   "",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "",
+				State:             Incomplete,
+				Weight:            "",
+				Slug:              "",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
 						Videos: nil,
 					},
-					SectionTitles: []string{},
+					SectionTitles:    []string{},
+					SectionPositions: map[string]Position{},
+				},
+				Anchors: map[string]struct{}{},
+				Positions: map[string]Position{
+					"state": {Line: 2, Column: 1},
 				},
-				Links: map[string]string{},
+				SectionPositions: map[string]Position{},
 			},
 		},
 		{
@@ -76,15 +88,21 @@ title = "Prepare"
 +++`,
 			},
 			want: Content{
-				Title: "Prepare",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
 						Videos: nil,
 					},
-					SectionTitles: []string{},
+					SectionTitles:    []string{},
+					SectionPositions: map[string]Position{},
+				},
+				Anchors: map[string]struct{}{},
+				Positions: map[string]Position{
+					"title": {Line: 2, Column: 1},
 				},
-				Links: map[string]string{},
+				SectionPositions: map[string]Position{},
 			},
 		},
 		{
@@ -95,15 +113,21 @@ state = "incomplete"
 +++`,
 			},
 			want: Content{
-				State: Incomplete,
+				FrontMatterFormat: TOMLFrontMatter,
+				State:             Incomplete,
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
 						Videos: nil,
 					},
-					SectionTitles: []string{},
+					SectionTitles:    []string{},
+					SectionPositions: map[string]Position{},
 				},
-				Links: map[string]string{},
+				Anchors: map[string]struct{}{},
+				Positions: map[string]Position{
+					"state": {Line: 2, Column: 1},
+				},
+				SectionPositions: map[string]Position{},
 			},
 		},
 		{
@@ -120,12 +144,22 @@ Episodes
 `,
 			},
 			want: Content{
-				Title: "Prepare",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
 				Body: &IndexBody{
 					HasEpisodes: true,
 					State:       Incomplete,
 				},
-				Links: map[string]string{},
+				Anchors: map[string]struct{}{
+					"episodes": {},
+				},
+				Positions: map[string]Position{
+					"archetype": {Line: 2, Column: 1},
+					"title":     {Line: 3, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"episodes": {Line: 5, Column: 1},
+				},
 			},
 		},
 		{
@@ -143,13 +177,24 @@ Episodes
 `,
 			},
 			want: Content{
-				Title: "Prepare",
-				State: Complete,
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
+				State:             Complete,
 				Body: &IndexBody{
 					HasEpisodes: true,
 					State:       Incomplete,
 				},
-				Links: map[string]string{},
+				Anchors: map[string]struct{}{
+					"episodes": {},
+				},
+				Positions: map[string]Position{
+					"archetype": {Line: 2, Column: 1},
+					"title":     {Line: 3, Column: 1},
+					"state":     {Line: 4, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"episodes": {Line: 6, Column: 1},
+				},
 			},
 		},
 		{
@@ -189,10 +234,11 @@ Exercises
 `,
 			},
 			want: Content{
-				Title:  "Prepare",
-				State:  Complete,
-				Weight: "",
-				Slug:   "",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
+				State:             Complete,
+				Weight:            "",
+				Slug:              "",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
@@ -211,11 +257,38 @@ Exercises
 						sectionRelatedLinks,
 						sectionExercises,
 					},
+					SectionPositions: map[string]Position{
+						"summary":        {Line: 5, Column: 1},
+						"main video":     {Line: 10, Column: 1},
+						"topics":         {Line: 13, Column: 1},
+						"related videos": {Line: 18, Column: 1},
+						"related links":  {Line: 23, Column: 1},
+						"exercises":      {Line: 28, Column: 1},
+					},
 				},
 				EmptySections: []string{
 					"main video",
 				},
-				Links: map[string]string{},
+				Anchors: map[string]struct{}{
+					"exercises":      {},
+					"main-video":     {},
+					"related-links":  {},
+					"related-videos": {},
+					"summary":        {},
+					"topics":         {},
+				},
+				Positions: map[string]Position{
+					"title": {Line: 2, Column: 1},
+					"state": {Line: 3, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"summary":        {Line: 5, Column: 1},
+					"main video":     {Line: 10, Column: 1},
+					"topics":         {Line: 13, Column: 1},
+					"related videos": {Line: 18, Column: 1},
+					"related links":  {Line: 23, Column: 1},
+					"exercises":      {Line: 28, Column: 1},
+				},
 			},
 		},
 		{
@@ -257,10 +330,11 @@ Exercises
 `,
 			},
 			want: Content{
-				Title:  "Prepare",
-				State:  Complete,
-				Weight: "",
-				Slug:   "",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
+				State:             Complete,
+				Weight:            "",
+				Slug:              "",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
@@ -279,8 +353,35 @@ Exercises
 						sectionRelatedLinks,
 						sectionExercises,
 					},
+					SectionPositions: map[string]Position{
+						"summary":        {Line: 5, Column: 1},
+						"main video":     {Line: 10, Column: 1},
+						"topics":         {Line: 15, Column: 1},
+						"related videos": {Line: 20, Column: 1},
+						"related links":  {Line: 25, Column: 1},
+						"exercises":      {Line: 30, Column: 1},
+					},
+				},
+				Anchors: map[string]struct{}{
+					"exercises":      {},
+					"main-video":     {},
+					"related-links":  {},
+					"related-videos": {},
+					"summary":        {},
+					"topics":         {},
+				},
+				Positions: map[string]Position{
+					"title": {Line: 2, Column: 1},
+					"state": {Line: 3, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"summary":        {Line: 5, Column: 1},
+					"main video":     {Line: 10, Column: 1},
+					"topics":         {Line: 15, Column: 1},
+					"related videos": {Line: 20, Column: 1},
+					"related links":  {Line: 25, Column: 1},
+					"exercises":      {Line: 30, Column: 1},
 				},
-				Links: map[string]string{},
 			},
 		},
 		{
@@ -312,10 +413,11 @@ state = "complete"
 `,
 			},
 			want: Content{
-				Title:  "Prepare",
-				State:  Complete,
-				Weight: "",
-				Slug:   "",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
+				State:             Complete,
+				Weight:            "",
+				Slug:              "",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
@@ -333,8 +435,32 @@ state = "complete"
 						sectionRelatedVideos,
 						sectionRelatedLinks,
 					},
+					SectionPositions: map[string]Position{
+						"summary":        {Line: 5, Column: 1},
+						"main video":     {Line: 9, Column: 1},
+						"topics":         {Line: 13, Column: 1},
+						"related videos": {Line: 17, Column: 1},
+						"related links":  {Line: 21, Column: 1},
+					},
+				},
+				Anchors: map[string]struct{}{
+					"main-video":     {},
+					"related-links":  {},
+					"related-videos": {},
+					"summary":        {},
+					"topics":         {},
+				},
+				Positions: map[string]Position{
+					"title": {Line: 2, Column: 1},
+					"state": {Line: 3, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"summary":        {Line: 5, Column: 1},
+					"main video":     {Line: 9, Column: 1},
+					"topics":         {Line: 13, Column: 1},
+					"related videos": {Line: 17, Column: 1},
+					"related links":  {Line: 21, Column: 1},
 				},
-				Links: map[string]string{},
 			},
 		},
 		{
@@ -371,10 +497,11 @@ weight = 9
 `,
 			},
 			want: Content{
-				Title:  "Prepare",
-				State:  Complete,
-				Weight: "9",
-				Slug:   "",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Prepare",
+				State:             Complete,
+				Weight:            "9",
+				Slug:              "",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
@@ -393,8 +520,36 @@ weight = 9
 						sectionRelatedLinks,
 						sectionExercises,
 					},
+					SectionPositions: map[string]Position{
+						"summary":        {Line: 6, Column: 1},
+						"main video":     {Line: 10, Column: 1},
+						"topics":         {Line: 14, Column: 1},
+						"related videos": {Line: 18, Column: 1},
+						"related links":  {Line: 22, Column: 1},
+						"exercises":      {Line: 26, Column: 1},
+					},
+				},
+				Anchors: map[string]struct{}{
+					"exercises":      {},
+					"main-video":     {},
+					"related-links":  {},
+					"related-videos": {},
+					"summary":        {},
+					"topics":         {},
+				},
+				Positions: map[string]Position{
+					"title":  {Line: 2, Column: 1},
+					"state":  {Line: 3, Column: 1},
+					"weight": {Line: 4, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"summary":        {Line: 6, Column: 1},
+					"main video":     {Line: 10, Column: 1},
+					"topics":         {Line: 14, Column: 1},
+					"related videos": {Line: 18, Column: 1},
+					"related links":  {Line: 22, Column: 1},
+					"exercises":      {Line: 26, Column: 1},
 				},
-				Links: map[string]string{},
 			},
 		},
 		{
@@ -426,10 +581,11 @@ on your choice of text editor. :D
 `,
 			},
 			want: Content{
-				Title:  "What Your Text Editor Says About You",
-				State:  Complete,
-				Weight: "60",
-				Slug:   "what-your-text-editor-says-about-you",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "What Your Text Editor Says About You",
+				State:             Complete,
+				Weight:            "60",
+				Slug:              "what-your-text-editor-says-about-you",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoPresent,
@@ -450,11 +606,32 @@ on your choice of text editor. :D
 					SectionTitles: []string{
 						sectionMainVideo,
 					},
+					SectionPositions: map[string]Position{
+						"main video": {Line: 15, Column: 1},
+					},
 				},
 				Audience:   All,
 				Importance: Optional,
 				Tags:       []string{"no-exercise", "fun", "vim", "vscode", "goland", "jetbrains"},
-				Links:      map[string]string{},
+				Anchors: map[string]struct{}{
+					"main-video": {},
+				},
+				Positions: map[string]Position{
+					"title":              {Line: 2, Column: 1},
+					"date":               {Line: 3, Column: 1},
+					"weight":             {Line: 4, Column: 1},
+					"state":              {Line: 5, Column: 1},
+					"draft":              {Line: 6, Column: 1},
+					"slug":               {Line: 7, Column: 1},
+					"tags":               {Line: 8, Column: 1},
+					"disableMermaid":     {Line: 9, Column: 1},
+					"disableOpenapi":     {Line: 10, Column: 1},
+					"audience":           {Line: 11, Column: 1},
+					"audienceImportance": {Line: 12, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"main video": {Line: 15, Column: 1},
+				},
 			},
 		},
 		{
@@ -547,10 +724,11 @@ Example output:
 `,
 			},
 			want: Content{
-				Title:  "Data Cleanup",
-				State:  Complete,
-				Weight: "20",
-				Slug:   "data-cleanup",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Data Cleanup",
+				State:             Complete,
+				Weight:            "20",
+				Slug:              "data-cleanup",
 				Body: &PracticeBody{
 					HasDescription:           true,
 					HasRecommendedChallenges: true,
@@ -559,8 +737,41 @@ Example output:
 				Audience:   All,
 				Importance: Important,
 				Tags:       []string{"vim", "practice"},
-				Links: map[string]string{
-					"18:25": "/a1.1/practice-data-cleanup.sql",
+				Links: []Link{
+					{Kind: LinkKindAnchor, URL: "/a1.1/practice-data-cleanup.sql", Text: "this SQL File", Position: Position{Line: 18, Column: 26}},
+				},
+				Anchors: map[string]struct{}{
+					"additional-challenges":        {},
+					"description":                  {},
+					"display-overall-stats":        {},
+					"display-stats-for-each-chart": {},
+					"example-1":                    {},
+					"example-2":                    {},
+					"example-3":                    {},
+					"examples":                     {},
+					"find-the-size-of-chart-maps":  {},
+					"find-the-size-of-intended-chart-maps-and-errors": {},
+					"hints":                  {},
+					"recommended-challenges": {},
+					"sorting":                {},
+				},
+				Positions: map[string]Position{
+					"title":              {Line: 2, Column: 1},
+					"date":               {Line: 3, Column: 1},
+					"weight":             {Line: 4, Column: 1},
+					"state":              {Line: 5, Column: 1},
+					"draft":              {Line: 6, Column: 1},
+					"slug":               {Line: 7, Column: 1},
+					"tags":               {Line: 8, Column: 1},
+					"disableMermaid":     {Line: 9, Column: 1},
+					"disableOpenapi":     {Line: 10, Column: 1},
+					"audience":           {Line: 11, Column: 1},
+					"audienceImportance": {Line: 12, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"description":            {Line: 15, Column: 1},
+					"recommended challenges": {Line: 40, Column: 1},
+					"additional challenges":  {Line: 59, Column: 1},
 				},
 			},
 		},
@@ -595,10 +806,11 @@ Related Links
 `,
 			},
 			want: Content{
-				Title:  "Free Dev Learning",
-				State:  Incomplete,
-				Weight: "10",
-				Slug:   "free-dev-learning",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Free Dev Learning",
+				State:             Incomplete,
+				Weight:            "10",
+				Slug:              "free-dev-learning",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoMissing,
@@ -611,12 +823,38 @@ Related Links
 					HasRelatedLinks:    true,
 					UsefulWithoutVideo: true,
 					SectionTitles:      []string{sectionMainVideo, sectionRelatedLinks},
+					SectionPositions: map[string]Position{
+						"main video":    {Line: 15, Column: 1},
+						"related links": {Line: 20, Column: 1},
+					},
 				},
 				Audience:   All,
 				Importance: Relevant,
 				Tags:       []string{"career", "learning", "no-exercise", "useful-without-video"},
-				Links: map[string]string{
-					"25:13": "https://exercism.org/",
+				Links: []Link{
+					{Kind: LinkKindAnchor, URL: "https://exercism.org/", Text: "exercism", Position: Position{Line: 25, Column: 14}},
+				},
+				Anchors: map[string]struct{}{
+					"main-video":    {},
+					"platforms":     {},
+					"related-links": {},
+				},
+				Positions: map[string]Position{
+					"title":              {Line: 2, Column: 1},
+					"date":               {Line: 3, Column: 1},
+					"weight":             {Line: 4, Column: 1},
+					"state":              {Line: 5, Column: 1},
+					"draft":              {Line: 6, Column: 1},
+					"slug":               {Line: 7, Column: 1},
+					"tags":               {Line: 8, Column: 1},
+					"disableMermaid":     {Line: 9, Column: 1},
+					"disableOpenapi":     {Line: 10, Column: 1},
+					"audience":           {Line: 11, Column: 1},
+					"audienceImportance": {Line: 12, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"main video":    {Line: 15, Column: 1},
+					"related links": {Line: 20, Column: 1},
 				},
 			},
 		},
@@ -750,10 +988,11 @@ probably my favorite video recommended on this page.
 `,
 			},
 			want: Content{
-				Title:  "Electronic Computing",
-				State:  Complete,
-				Weight: "80",
-				Slug:   "electronic-computing",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Electronic Computing",
+				State:             Complete,
+				Weight:            "80",
+				Slug:              "electronic-computing",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoPresent,
@@ -845,15 +1084,62 @@ probably my favorite video recommended on this page.
 						sectionTopics,
 						sectionRelatedVideos,
 					},
+					SectionPositions: map[string]Position{
+						"main video":     {Line: 15, Column: 1},
+						"summary":        {Line: 25, Column: 1},
+						"topics":         {Line: 31, Column: 1},
+						"related videos": {Line: 37, Column: 1},
+					},
 				},
 				Audience:   All,
 				Importance: Optional,
 				Tags:       []string{"computer-science", "no-exercise"},
-				Links: map[string]string{
-					"20:28": "https://about.me/carrieannephilbin",
-					"20:83": "https://www.youtube.com/@crashcourse",
-					"34:19": "https://en.wikipedia.org/wiki/Harvard_Mark_I",
-					"35:21": "https://en.wikipedia.org/wiki/Relay",
+				Links: []Link{
+					{Kind: LinkKindAnchor, URL: "https://about.me/carrieannephilbin", Text: "Carrie Anne", Position: Position{Line: 20, Column: 29}},
+					{Kind: LinkKindAnchor, URL: "https://www.youtube.com/@crashcourse", Text: "Crash Course", Position: Position{Line: 20, Column: 84}},
+					{Kind: LinkKindAnchor, URL: "https://en.wikipedia.org/wiki/Harvard_Mark_I", Text: "Harvard Mark I", Position: Position{Line: 34, Column: 20}},
+					{Kind: LinkKindAnchor, URL: "https://en.wikipedia.org/wiki/Relay", Text: "Mechanical relay", Position: Position{Line: 35, Column: 22}},
+				},
+				Anchors: map[string]struct{}{
+					"ada-lovelace-the-first-computer-programmer---biographics":                                      {},
+					"babbages-analytical-engine---computerphile":                                                    {},
+					"colossus---the-greatest-secret-in-the-history-of-computing---the-centre-for-computing-history": {},
+					"colossus--bletchley-park---computerphile":                                                      {},
+					"enigma-bombe-alan-turing":                                                                      {},
+					"harvard-mark-i":                                                                                {},
+					"harvard-mark-i-2022---cs50":                                                                    {},
+					"how-did-the-enigma-machine-work---jared-owen":                                                  {},
+					"lorenz-and-colossus-tommy-flowers-bill-tutte":                                                  {},
+					"main-video":     {},
+					"related-videos": {},
+					"summary":        {},
+					"supercomputer-where-it-all-started---harvard-mark-1---major-hardware": {},
+					"the-analytical-engine-charles-babbage-ada-lovelace":                   {},
+					"the-greatest-machine-that-never-was---john-graham-cumming---ted-ed":   {},
+					"topics": {},
+					"transistors---the-invention-that-changed-the-world---real-engineering":               {},
+					"transistors-and-eniac-john-mauchly-j-presper-eckert":                                 {},
+					"why-build-colossus-bill-tutte---computerphile":                                       {},
+					"why-the-toughest-code-to-break-in-ww2-wasnt-enigma---the-story-of-the-lorenz-cipher": {},
+				},
+				Positions: map[string]Position{
+					"title":              {Line: 2, Column: 1},
+					"date":               {Line: 3, Column: 1},
+					"weight":             {Line: 4, Column: 1},
+					"state":              {Line: 5, Column: 1},
+					"draft":              {Line: 6, Column: 1},
+					"slug":               {Line: 7, Column: 1},
+					"tags":               {Line: 8, Column: 1},
+					"disableMermaid":     {Line: 9, Column: 1},
+					"disableOpenapi":     {Line: 10, Column: 1},
+					"audience":           {Line: 11, Column: 1},
+					"audienceImportance": {Line: 12, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"main video":     {Line: 15, Column: 1},
+					"summary":        {Line: 25, Column: 1},
+					"topics":         {Line: 31, Column: 1},
+					"related videos": {Line: 37, Column: 1},
 				},
 			},
 		},
@@ -907,10 +1193,11 @@ Exercises
 `,
 			},
 			want: Content{
-				Title:  "Advanced Linux Commands",
-				State:  Incomplete,
-				Weight: "40",
-				Slug:   "advanced-linux-commands",
+				FrontMatterFormat: TOMLFrontMatter,
+				Title:             "Advanced Linux Commands",
+				State:             Incomplete,
+				Weight:            "40",
+				Slug:              "advanced-linux-commands",
 				Body: DefaultBody{
 					Main: Main{
 						Status: VideoProblem,
@@ -940,14 +1227,50 @@ Exercises
 						sectionExercises,
 					},
 					UsefulWithoutVideo: false,
+					SectionPositions: map[string]Position{
+						"summary":        {Line: 15, Column: 1},
+						"topics":         {Line: 18, Column: 1},
+						"main video":     {Line: 24, Column: 1},
+						"related videos": {Line: 27, Column: 1},
+						"exercises":      {Line: 42, Column: 1},
+					},
 				},
 				Audience:      All,
 				Importance:    Important,
 				Tags:          []string{"linux", "cli"},
 				EmptySections: []string{"summary", "main video", "exercises"},
-				Links: map[string]string{
-					"21:10": "https://linux.die.net/man/1/which",
-					"22:9":  "https://linux.die.net/man/1/ping",
+				Links: []Link{
+					{Kind: LinkKindAnchor, URL: "https://linux.die.net/man/1/which", Text: "which", Position: Position{Line: 21, Column: 11}},
+					{Kind: LinkKindAnchor, URL: "https://linux.die.net/man/1/ping", Text: "ping", Position: Position{Line: 22, Column: 10}},
+				},
+				Anchors: map[string]struct{}{
+					"50-must-know-linux-commands-in-under-15-minutes": {},
+					"exercises":                        {},
+					"linux-command-line-for-beginners": {},
+					"main-video":                       {},
+					"related-videos":                   {},
+					"summary":                          {},
+					"topics":                           {},
+				},
+				Positions: map[string]Position{
+					"title":              {Line: 2, Column: 1},
+					"date":               {Line: 3, Column: 1},
+					"weight":             {Line: 4, Column: 1},
+					"state":              {Line: 5, Column: 1},
+					"draft":              {Line: 6, Column: 1},
+					"slug":               {Line: 7, Column: 1},
+					"tags":               {Line: 8, Column: 1},
+					"disableMermaid":     {Line: 9, Column: 1},
+					"disableOpenapi":     {Line: 10, Column: 1},
+					"audience":           {Line: 11, Column: 1},
+					"audienceImportance": {Line: 12, Column: 1},
+				},
+				SectionPositions: map[string]Position{
+					"summary":        {Line: 15, Column: 1},
+					"topics":         {Line: 18, Column: 1},
+					"main video":     {Line: 24, Column: 1},
+					"related videos": {Line: 27, Column: 1},
+					"exercises":      {Line: 42, Column: 1},
 				},
 			},
 		},
@@ -994,8 +1317,8 @@ func TestExtractRelatedVideos(t *testing.T) {
 			want: Videos{
 				{
 					Badges: Badges{},
-					Issues: []string{
-						"missing badge shortcode",
+					Issues: []Issue{
+						NewIssue(RuleBadgeMissing, "missing badge shortcode").At(Position{Line: 2, Column: 1}),
 					},
 					Minutes: 5,
 					Valid:   true,
@@ -1017,10 +1340,10 @@ func TestExtractRelatedVideos(t *testing.T) {
 			want: Videos{
 				{
 					Badges: Badges{Extra, Extra},
-					Issues: []string{
-						"multiple time shortcodes found",
-						"unexpected badge shortcode found: extra",
-						"multiple youtube shortcodes found",
+					Issues: []Issue{
+						NewIssue(RuleTimeMultiple, "multiple time shortcodes found").At(Position{Line: 2, Column: 16}),
+						NewIssue(RuleBadgeUnexpected, "unexpected badge shortcode found: extra").At(Position{Line: 4, Column: 24}),
+						NewIssue(RuleYoutubeMultiple, "multiple youtube shortcodes found").At(Position{Line: 6, Column: 21}),
 					},
 					Minutes: 5,
 					Valid:   true,
@@ -1058,25 +1381,25 @@ foo
 			want: Videos{
 				{
 					Badges: Badges{},
-					Issues: []string{
-						"missing badge shortcode",
+					Issues: []Issue{
+						NewIssue(RuleBadgeMissing, "missing badge shortcode").At(Position{Line: 7, Column: 1}),
 					},
 					Minutes: 5,
 					Valid:   true,
 				},
 				{
 					Badges: Badges{Alternative, Extra},
-					Issues: []string{
-						"unexpected badge shortcode found: extra",
-						"badges should have full-course, but do not. badges: alternative, extra",
+					Issues: []Issue{
+						NewIssue(RuleBadgeUnexpected, "unexpected badge shortcode found: extra").At(Position{Line: 13, Column: 42}),
+						NewIssue(RuleBadgeLength, "badges should have full-course, but do not. badges: alternative, extra").At(Position{Line: 13, Column: 1}),
 					},
 					Minutes: 123,
 					Valid:   true,
 				},
 				{
 					Badges: Badges{Extra},
-					Issues: []string{
-						"multiple youtube shortcodes found",
+					Issues: []Issue{
+						NewIssue(RuleYoutubeMultiple, "multiple youtube shortcodes found").At(Position{Line: 22, Column: 1}),
 					},
 					Minutes: 17,
 					Valid:   true,
@@ -1156,7 +1479,7 @@ foo
 			want: Videos{
 				{
 					Badges:  Badges{Extra},
-					Issues:  []string{"unexpected youtube shortcode together with no-embed badge"},
+					Issues:  []Issue{NewIssue(RuleYoutubeUnexpected, "unexpected youtube shortcode together with no-embed badge").At(Position{Line: 5, Column: 1})},
 					Minutes: 17,
 					Valid:   true,
 				},
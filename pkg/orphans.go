@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+func normalizeInternalLink(link string) string {
+	link, _ = SplitFragment(link)
+
+	if link != "" && !strings.HasSuffix(link, "/") {
+		link += "/"
+	}
+
+	return link
+}
+
+func (c Courses) getLinkedPages(root string) map[string]struct{} {
+	linked := make(map[string]struct{})
+	index := c.newRefIndex(root)
+	claims := c.getURLClaims(root)
+
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				self := chapter.GetInternalLink(root, page)
+
+				for _, link := range page.Content.Links {
+					target := ""
+
+					switch {
+					case link.Kind == LinkKindRef:
+						ref, _ := SplitFragment(link.URL)
+						if pages := index.resolve(page, ref); len(pages) == 1 {
+							target = index.links[pages[0].FileName]
+						}
+					default:
+						if resolved := resolveInternalLink(self, link.URL); strings.HasPrefix(resolved, "/") {
+							target = normalizeInternalLink(resolved)
+						}
+					}
+
+					if target == "" || target == self {
+						continue
+					}
+
+					linked[target] = struct{}{}
+
+					// links to aliases reach the pages the aliases redirect to
+					for _, claim := range claims[target] {
+						if claim.alias {
+							linked[claim.link] = struct{}{}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return linked
+}
+
+// GetOrphanIssues never reports chapter indexes, as they are reachable from the menu.
+func (c Courses) GetOrphanIssues(root string, entryPoints []string) []Record {
+	linked := c.getLinkedPages(root)
+	for _, entryPoint := range entryPoints {
+		linked[normalizeInternalLink(entryPoint)] = struct{}{}
+	}
+
+	var records []Record
+
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				if page.Title == "_index.md" {
+					continue
+				}
+
+				link := chapter.GetInternalLink(root, page)
+				if _, ok := linked[link]; ok {
+					continue
+				}
+
+				issue := NewIssue(RuleOrphanPage, fmt.Sprintf("page is not linked from any other page: %s", link))
+				records = append(records, NewRecord(page.FileName, page.Course, page.Chapter, page.Title, issue))
+			}
+		}
+	}
+
+	return filterRecords(records)
+}
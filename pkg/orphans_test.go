@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCourses_GetOrphanIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+	}{
+		{name: "current directory", root: "."},
+		{name: "subdirectory", root: "site"},
+		{name: "absolute", root: "/srv/site"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := filepath.Join(tt.root, "content")
+
+			newPage := func(filePath, slug string, links ...string) Page {
+				content := Content{Slug: slug}
+				for i, link := range links {
+					content.Links = append(content.Links, Link{Kind: LinkKindAnchor, URL: link, Position: Position{Line: i + 1, Column: 1}})
+				}
+
+				return newTestPage(filepath.Join(contentDir, filePath), content)
+			}
+
+			courses := Courses{
+				{
+					Course: "go",
+					Chapters: Chapters{
+						{
+							Course:  "go",
+							Chapter: "basics",
+							Pages: Pages{
+								newPage("go/basics/_index.md", "", "/go/basics/variables/"),
+								newPage("go/basics/10-variables.md", "variables", "/go/basics/loops#exercises", "/go/basics/variables/#summary"),
+								newPage("go/basics/20-loops.md", "loops", "../sibling/#summary"),
+								newPage("go/basics/30-self.md", "self", "/go/basics/self/"),
+								newPage("go/basics/40-entry.md", "entry"),
+								newPage("go/basics/50-outside.md", "outside", "https://example.com/go/basics/outside/"),
+								newPage("go/basics/60-sibling.md", "sibling"),
+							},
+						},
+					},
+				},
+			}
+
+			// execute
+			got := courses.GetOrphanIssues(tt.root, []string{"/go/basics/entry"})
+
+			// verify
+			var messages []string
+			for _, record := range got {
+				messages = append(messages, strings.TrimPrefix(record.Location(), contentDir+"/")+" "+record.Message)
+			}
+			assert.Equal(t, []string{
+				"go/basics/30-self.md page is not linked from any other page: /go/basics/self/",
+				"go/basics/50-outside.md page is not linked from any other page: /go/basics/outside/",
+			}, messages)
+		})
+	}
+}
@@ -8,7 +8,7 @@ type PracticeBody struct {
 	HasAdditionalChallenges  bool
 }
 
-func (pb PracticeBody) GetIssues(_ State) []string {
+func (pb PracticeBody) GetIssues(_ State) []Issue {
 	return nil
 }
 
@@ -0,0 +1,140 @@
+package pkg
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// refKey finds section indexes and leaf bundles by the path of their directory, just like Hugo.
+func refKey(filePath string) string {
+	key := strings.Trim(path.Clean("/"+filePath), "/")
+	key = strings.TrimSuffix(key, path.Ext(key))
+
+	for _, index := range []string{"_index", "index"} {
+		if key == index {
+			return ""
+		}
+
+		key = strings.TrimSuffix(key, "/"+index)
+	}
+
+	return key
+}
+
+func contentPath(root, fileName string) string {
+	rel, err := filepath.Rel(filepath.Join(root, "content"), fileName)
+	if err != nil {
+		return filepath.ToSlash(fileName)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+type refIndex struct {
+	byPath map[string]Page
+	byName map[string][]Page
+	paths  map[string]string
+	links  map[string]string
+}
+
+func (c Courses) newRefIndex(root string) refIndex {
+	index := refIndex{
+		byPath: make(map[string]Page),
+		byName: make(map[string][]Page),
+		paths:  make(map[string]string),
+		links:  make(map[string]string),
+	}
+
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				filePath := contentPath(root, page.FileName)
+				key := refKey(filePath)
+				name := path.Base(key)
+
+				index.byPath[key] = page
+				index.byName[name] = append(index.byName[name], page)
+				index.paths[page.FileName] = filePath
+				index.links[page.FileName] = chapter.GetInternalLink(root, page)
+			}
+		}
+	}
+
+	return index
+}
+
+// resolve tries the ref relative to the page first, then from the root of the content. Refs without a directory are
+// looked up by file name as well, which is ambiguous if multiple pages share the name.
+func (r refIndex) resolve(from Page, ref string) []Page {
+	if ref == "" {
+		return []Page{from}
+	}
+
+	if !strings.HasPrefix(ref, "/") {
+		dir := path.Dir(r.paths[from.FileName])
+		if page, ok := r.byPath[refKey(path.Join(dir, ref))]; ok {
+			return []Page{page}
+		}
+	}
+
+	if page, ok := r.byPath[refKey(ref)]; ok {
+		return []Page{page}
+	}
+
+	if strings.Contains(strings.Trim(ref, "/"), "/") {
+		return nil
+	}
+
+	return r.byName[refKey(ref)]
+}
+
+func (c Courses) GetRefIssues(root string) []Record {
+	index := c.newRefIndex(root)
+
+	var records []Record
+
+	for _, course := range c {
+		for _, chapter := range course.Chapters {
+			for _, page := range chapter.Pages {
+				for _, link := range page.Content.Links {
+					if link.Kind != LinkKindRef {
+						continue
+					}
+
+					ref, fragment := SplitFragment(link.URL)
+
+					var issue Issue
+
+					pages := index.resolve(page, ref)
+					switch {
+					case len(pages) == 0:
+						issue = NewIssue(RuleRefNotFound, fmt.Sprintf("ref not found: %s", link.URL))
+					case len(pages) > 1:
+						fileNames := make([]string, 0, len(pages))
+						for _, candidate := range pages {
+							fileNames = append(fileNames, candidate.FileName)
+						}
+						slices.Sort(fileNames)
+
+						issue = NewIssue(RuleRefAmbiguous, fmt.Sprintf("ref is ambiguous: %s, pages: %s", link.URL, strings.Join(fileNames, ", ")))
+					case fragment != "":
+						if _, ok := pages[0].Content.Anchors[fragment]; ok {
+							continue
+						}
+
+						issue = NewIssue(RuleInternalLinkAnchor, fmt.Sprintf("internal link anchor not found: %s", link.URL))
+					default:
+						continue
+					}
+
+					records = append(records, NewRecord(page.FileName, page.Course, page.Chapter, page.Title, issue.At(link.Position)))
+				}
+			}
+		}
+	}
+
+	return filterRecords(records)
+}
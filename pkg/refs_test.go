@@ -0,0 +1,119 @@
+package pkg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_refKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{name: "page", filePath: "go/basics/10-variables.md", want: "go/basics/10-variables"},
+		{name: "without extension", filePath: "/go/basics/10-variables", want: "go/basics/10-variables"},
+		{name: "section", filePath: "go/basics/_index.md", want: "go/basics"},
+		{name: "section directory", filePath: "go/basics/", want: "go/basics"},
+		{name: "leaf bundle", filePath: "go/basics/loops/index.md", want: "go/basics/loops"},
+		{name: "content root", filePath: "_index.md", want: ""},
+		{name: "relative", filePath: "go/advanced/../basics/./10-variables.md", want: "go/basics/10-variables"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := refKey(tt.filePath)
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCourses_GetRefIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+	}{
+		{name: "current directory", root: "."},
+		{name: "subdirectory", root: "site"},
+		{name: "nested subdirectory", root: "sites/content"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := filepath.Join(tt.root, "content")
+
+			newPage := func(filePath string, refs ...string) Page {
+				content := Content{Anchors: map[string]struct{}{"summary": {}}}
+				for i, ref := range refs {
+					content.Links = append(content.Links, Link{Kind: LinkKindRef, URL: ref, Position: Position{Line: i + 1, Column: 10}})
+				}
+
+				return newTestPage(filepath.Join(contentDir, filePath), content)
+			}
+
+			courses := Courses{
+				{
+					Course: "go",
+					Chapters: Chapters{
+						{
+							Course:  "go",
+							Chapter: "basics",
+							Pages: Pages{
+								newPage("go/basics/_index.md"),
+								newPage("go/basics/10-variables.md"),
+								newPage(
+									"go/basics/20-loops.md",
+									"10-variables.md",
+									"/python/basics/10-variables",
+									"go/basics/10-variables.md#summary",
+									"10-variables#missing",
+									"../../python/basics",
+									"#summary",
+								),
+							},
+						},
+						{
+							Course:  "go",
+							Chapter: "advanced",
+							Pages: Pages{
+								newPage("go/advanced/10-generics.md", "10-variables", "20-loops", "missing", "basics/10-variables"),
+							},
+						},
+					},
+				},
+				{
+					Course: "python",
+					Chapters: Chapters{
+						{
+							Course:  "python",
+							Chapter: "basics",
+							Pages: Pages{
+								newPage("python/basics/_index.md"),
+								newPage("python/basics/10-variables.md"),
+							},
+						},
+					},
+				},
+			}
+
+			// execute
+			got := courses.GetRefIssues(tt.root)
+
+			// verify
+			var messages []string
+			for _, record := range got {
+				messages = append(messages, strings.TrimPrefix(record.Location(), contentDir+"/")+" "+record.Message)
+			}
+			assert.Equal(t, []string{
+				"go/basics/20-loops.md:4:10 internal link anchor not found: 10-variables#missing",
+				"go/advanced/10-generics.md:1:10 ref is ambiguous: 10-variables, pages: " + contentDir + "/go/basics/10-variables.md, " + contentDir + "/python/basics/10-variables.md",
+				"go/advanced/10-generics.md:3:10 ref not found: missing",
+				"go/advanced/10-generics.md:4:10 ref not found: basics/10-variables",
+			}, messages)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"cmp"
+	"slices"
+)
+
+const pageWeightStep = 10
+
+// GetPageWeights orders pages with the same weight by their file names.
+func (c *Chapter) GetPageWeights() map[string]int {
+	pages := make([]Page, 0, len(c.Pages))
+	for _, page := range c.Pages {
+		if page.Title == "_index.md" {
+			continue
+		}
+
+		pages = append(pages, page)
+	}
+
+	slices.SortStableFunc(pages, func(a, b Page) int {
+		return cmp.Or(cmp.Compare(a.GetWeight(), b.GetWeight()), cmp.Compare(a.FileName, b.FileName))
+	})
+
+	weights := make(map[string]int, len(pages))
+	for i, page := range pages {
+		weights[page.FileName] = (i + 1) * pageWeightStep
+	}
+
+	return weights
+}
+
+// GetChapterWeights leaves out chapters without an index page, as they have no weight to change.
+func (c Course) GetChapterWeights() map[string]int {
+	chapters := make([]*Chapter, 0, len(c.Chapters))
+	for _, chapter := range c.Chapters {
+		if !chapter.HasIndex() {
+			continue
+		}
+
+		chapters = append(chapters, chapter)
+	}
+
+	slices.SortStableFunc(chapters, func(a, b *Chapter) int {
+		return cmp.Or(cmp.Compare(a.GetWeight(), b.GetWeight()), cmp.Compare(a.Chapter, b.Chapter))
+	})
+
+	weights := make(map[string]int, len(chapters))
+	for i, chapter := range chapters {
+		weights[chapter.GetIndexFileName()] = i + 1
+	}
+
+	return weights
+}
@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChapter_GetPageWeights(t *testing.T) {
+	chapter := &Chapter{
+		Course:  "go",
+		Chapter: "basics",
+		Pages: Pages{
+			{FileName: "go/basics/_index.md", Title: "_index.md", Content: Content{Weight: "1"}},
+			{FileName: "go/basics/25-loops.md", Title: "25-loops.md", Content: Content{Weight: "25"}},
+			{FileName: "go/basics/10-variables.md", Title: "10-variables.md", Content: Content{Weight: "10"}},
+			{FileName: "go/basics/10-constants.md", Title: "10-constants.md", Content: Content{Weight: "10"}},
+			{FileName: "go/basics/70-functions.md", Title: "70-functions.md", Content: Content{Weight: "70"}},
+		},
+	}
+
+	// execute
+	got := chapter.GetPageWeights()
+
+	// verify
+	assert.Equal(t, map[string]int{
+		"go/basics/10-constants.md": 10,
+		"go/basics/10-variables.md": 20,
+		"go/basics/25-loops.md":     30,
+		"go/basics/70-functions.md": 40,
+	}, got)
+}
+
+func TestCourse_GetChapterWeights(t *testing.T) {
+	newChapter := func(name, weight string) *Chapter {
+		return &Chapter{
+			Course:  "go",
+			Chapter: name,
+			Pages: Pages{
+				{FileName: "go/" + name + "/_index.md", Title: "_index.md", Content: Content{Weight: weight}},
+			},
+		}
+	}
+
+	course := Course{
+		Course: "go",
+		Chapters: Chapters{
+			newChapter("advanced", "5"),
+			newChapter("basics", "2"),
+			newChapter("intro", "2"),
+			{Course: "go", Chapter: "no-index", Pages: Pages{{FileName: "go/no-index/10-foo.md", Title: "10-foo.md"}}},
+		},
+	}
+
+	// execute
+	got := course.GetChapterWeights()
+
+	// verify
+	assert.Equal(t, map[string]int{
+		"go/basics/_index.md":   1,
+		"go/intro/_index.md":    2,
+		"go/advanced/_index.md": 3,
+	}, got)
+}
+
+func TestPage_GetWeightFix(t *testing.T) {
+	rawContent := "+++\ntitle = \"Loops\"\nweight = 25\nslug = \"loops\"\n+++\n\nbody\n"
+
+	page := Page{
+		FileName: "content/go/basics/25-loops.md",
+		Title:    "25-loops.md",
+		Content:  Content{Weight: "25", Slug: "loops", Body: DefaultBody{}},
+	}
+
+	// execute
+	got, err := page.GetWeightFix(rawContent, 30)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "content/go/basics/30-loops.md", got.NewFilePath)
+	assert.Equal(t, "+++\ntitle = \"Loops\"\nweight = 30\nslug = \"loops\"\n+++\n\nbody\n", got.Fixed)
+	assert.Equal(t, []string{"weight: 25 => 30", "file name: 25-loops.md => 30-loops.md"}, got.Changes)
+}
@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type Format string
+
+const (
+	TextFormat  Format = "text"
+	JSONFormat  Format = "json"
+	SarifFormat Format = "sarif"
+)
+
+func ParseFormat(raw string) (Format, error) {
+	switch format := Format(raw); format {
+	case TextFormat, JSONFormat, SarifFormat:
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unknown format: %s", raw)
+}
+
+func WriteRecords(w io.Writer, format Format, records []Record, root, version string) error {
+	switch format {
+	case JSONFormat:
+		return WriteJSON(w, records)
+	case SarifFormat:
+		return WriteSarif(w, records, root, version)
+	}
+
+	return WriteText(w, records)
+}
+
+func WriteText(w io.Writer, records []Record) error {
+	files := make(map[string]struct{})
+
+	for _, record := range records {
+		files[record.FilePath] = struct{}{}
+
+		if _, err := fmt.Fprintln(w, record); err != nil {
+			return err
+		}
+	}
+
+	warnings := countSeverity(records, SeverityWarning)
+	if warnings == 0 {
+		_, err := fmt.Fprintln(w, "Found", len(records), "errors in", len(files), "files.")
+
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, "Found", len(records)-warnings, "errors and", warnings, "warnings in", len(files), "files.")
+
+	return err
+}
+
+// HasErrors ignores warnings, which alone should not fail a build.
+func HasErrors(records []Record) bool {
+	return countSeverity(records, SeverityError) > 0
+}
+
+func countSeverity(records []Record, severity Severity) int {
+	count := 0
+
+	for _, record := range records {
+		if record.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+func WriteJSON(w io.Writer, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+
+	return writeJSON(w, records)
+}
+
+func WriteSarif(w io.Writer, records []Record, root, version string) error {
+	return writeJSON(w, NewSarifLog(records, root, version))
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+
+	return err
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import "sort"
+
+// Codes are stable and never reused, so they can be relied on for filtering and suppressing issues.
+type RuleInfo struct {
+	Code        string
+	Rule        Rule
+	Severity    Severity
+	Description string
+}
+
+var ruleInfos = []RuleInfo{
+	// front matter and file names
+	{"CC101", RuleSlugMismatch, SeverityError, "slug does not match the title"},
+	{"CC102", RuleFileNameWeight, SeverityError, "file name is not prefixed with the weight"},
+	{"CC103", RuleFileNameSlug, SeverityError, "file name is not the dash joined weight and slug"},
+	{"CC104", RuleChapterSlug, SeverityError, "chapter directory does not match the slug of the title"},
+	{"CC105", RuleInvalidAudience, SeverityError, "audience is not one of the allowed audiences"},
+	{"CC106", RuleImportanceOrder, SeverityError, "importance is lower than outside importance"},
+	{"CC107", RuleOutsideImportance, SeverityError, "outside importance does not fit the audience"},
+	{"CC108", RuleTagUnsorted, SeverityError, "page is tagged as unsorted"},
+	{"CC109", RuleTagCase, SeverityError, "tag is not lowercase"},
+	{"CC110", RuleTagSpaces, SeverityError, "tag contains spaces"},
+	{"CC111", RuleStateMismatch, SeverityError, "state does not match the calculated state"},
+	{"CC112", RuleFileUnreadable, SeverityError, "file could not be read"},
+	{"CC113", RuleFileEmpty, SeverityError, "file is empty"},
+	{"CC114", RuleFrontMatterInvalid, SeverityError, "front matter could not be split from the body"},
+	{"CC115", RuleFrontMatterFormat, SeverityError, "front matter is not in the configured format"},
+	{"CC116", RuleFrontMatterSyntax, SeverityError, "front matter is not valid TOML, YAML or JSON"},
+	{"CC117", RuleFrontMatterType, SeverityError, "front matter value has the wrong type"},
+	{"CC118", RuleFrontMatterUnknownKey, SeverityWarning, "front matter key is not known, probably misspelled"},
+
+	// sections
+	{"CC201", RuleEmptySections, SeverityError, "complete page has empty sections"},
+	{"CC202", RuleSectionOrder, SeverityError, "sections are not in the expected order"},
+	{"CC203", RuleSummaryMissing, SeverityError, "summary section is missing"},
+	{"CC204", RuleTopicsMissing, SeverityError, "topics section is missing"},
+	{"CC205", RuleMainVideoMissing, SeverityError, "main video is missing without an alternative"},
+	{"CC206", RuleMainVideoNotMissing, SeverityError, "main video is marked really missing, but the page is useful without it"},
+
+	// videos and shortcodes
+	{"CC301", RuleTimeMissing, SeverityError, "video has no time shortcode"},
+	{"CC302", RuleTimeInvalid, SeverityError, "time shortcode cannot be parsed"},
+	{"CC303", RuleTimeMultiple, SeverityError, "video has multiple time shortcodes"},
+	{"CC304", RuleBadgeUnknown, SeverityError, "badge is unknown"},
+	{"CC305", RuleBadgeMissing, SeverityError, "video has no badge shortcode"},
+	{"CC306", RuleBadgeUnexpected, SeverityError, "video has more than one level badge"},
+	{"CC307", RuleBadgeOrder, SeverityError, "badge is placed before the time shortcode"},
+	{"CC308", RuleBadgeLength, SeverityError, "badge does not fit the length of the video"},
+	{"CC309", RuleYoutubeMissing, SeverityError, "video has no youtube shortcode"},
+	{"CC310", RuleYoutubeUnexpected, SeverityError, "youtube shortcode is used together with the no-embed badge"},
+	{"CC311", RuleYoutubeMultiple, SeverityError, "video has multiple youtube shortcodes"},
+
+	// page and chapter order
+	{"CC401", RulePageWeight, SeverityError, "page weight is not a multiple of 10"},
+	{"CC402", RulePageMissing, SeverityError, "chapter has no pages"},
+	{"CC403", RulePageWeightDuplicate, SeverityError, "pages share the same weight"},
+	{"CC404", RulePageWeightGap, SeverityError, "page weights have gaps"},
+	{"CC405", RuleChapterMissing, SeverityError, "course has no chapters"},
+	{"CC406", RuleChapterWeightDuplicate, SeverityError, "chapters share the same weight"},
+	{"CC407", RuleChapterWeightGap, SeverityError, "chapter weights have gaps"},
+
+	// links
+	{"CC501", RuleInternalLinkNotFound, SeverityError, "internal link points to a missing page"},
+	{"CC502", RuleExternalLinkStatus, SeverityError, "external link does not return 200 OK"},
+	{"CC503", RuleFileLinkNotFound, SeverityError, "linked file does not exist"},
+	{"CC504", RuleInternalLinkAnchor, SeverityError, "internal link points to a missing heading"},
+	{"CC505", RuleExternalLinkMoved, SeverityWarning, "external link is permanently redirected"},
+	{"CC506", RuleExternalRedirectLoop, SeverityError, "external link redirects in a loop"},
+	{"CC507", RuleExternalRedirectDomain, SeverityWarning, "external link redirects to another domain"},
+	{"CC508", RuleExternalLinkError, SeverityError, "external link could not be fetched"},
+	{"CC509", RuleOrphanPage, SeverityWarning, "page is not linked from any other page"},
+	{"CC510", RuleAssetUnreferenced, SeverityWarning, "static file is not referenced by any page"},
+	{"CC511", RuleFileLinkCase, SeverityError, "linked file only exists with a different case"},
+	{"CC512", RuleImageAltMissing, SeverityWarning, "image has no alt text"},
+	{"CC513", RuleReferenceUndefined, SeverityError, "reference link has no definition"},
+	{"CC514", RuleRefNotFound, SeverityError, "ref or relref shortcode does not resolve to a page"},
+	{"CC515", RuleRefAmbiguous, SeverityError, "ref or relref shortcode matches multiple pages"},
+	{"CC516", RuleInternalLinkSlash, SeverityWarning, "internal link is missing the trailing slash"},
+	{"CC517", RuleAliasCollision, SeverityError, "URL is claimed by multiple pages"},
+
+	// suppressions
+	{"CC601", RuleSuppressionUnused, SeverityWarning, "suppression does not match any issue"},
+	{"CC602", RuleSuppressionUnknown, SeverityError, "suppression refers to an unknown rule"},
+	{"CC603", RuleSuppressionUnsupported, SeverityError, "suppression refers to a rule which cannot be suppressed"},
+}
+
+var (
+	rulesByName = make(map[Rule]RuleInfo, len(ruleInfos))
+	rulesByCode = make(map[string]RuleInfo, len(ruleInfos))
+)
+
+func init() {
+	for _, info := range ruleInfos {
+		rulesByName[info.Rule] = info
+		rulesByCode[info.Code] = info
+	}
+}
+
+func Rules() []RuleInfo {
+	result := make([]RuleInfo, len(ruleInfos))
+	copy(result, ruleInfos)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+func LookupRule(nameOrCode string) (RuleInfo, bool) {
+	if info, ok := rulesByName[Rule(nameOrCode)]; ok {
+		return info, true
+	}
+
+	info, ok := rulesByCode[nameOrCode]
+
+	return info, ok
+}
+
+func (r Rule) Info() RuleInfo {
+	if info, ok := rulesByName[r]; ok {
+		return info
+	}
+
+	return RuleInfo{Rule: r, Severity: SeverityError}
+}
+
+func (r Rule) Code() string {
+	return r.Info().Code
+}
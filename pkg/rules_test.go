@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	codes := make(map[string]struct{})
+	names := make(map[Rule]struct{})
+
+	for _, info := range Rules() {
+		assert.Regexp(t, `^CC\d{3}$`, info.Code)
+		assert.NotEmpty(t, info.Description, info.Code)
+
+		assert.NotContains(t, codes, info.Code)
+		assert.NotContains(t, names, info.Rule)
+
+		codes[info.Code] = struct{}{}
+		names[info.Rule] = struct{}{}
+	}
+}
+
+func TestLookupRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		wantRule Rule
+		wantOK   bool
+	}{
+		{
+			name:     "name",
+			arg:      "slug-mismatch",
+			wantRule: RuleSlugMismatch,
+			wantOK:   true,
+		},
+		{
+			name:     "code",
+			arg:      "CC101",
+			wantRule: RuleSlugMismatch,
+			wantOK:   true,
+		},
+		{
+			name:   "unknown",
+			arg:    "foo",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got, ok := LookupRule(tt.arg)
+
+			// verify
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantRule, got.Rule)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package pkg
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "content-checker"
+	toolURI      = "https://github.com/devwithpeet/content-checker"
+	// sarifSourceRoot is the base of the artifact URIs, which are relative to the root of the site
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SarifMessage `json:"shortDescription"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// NewSarifLog converts records into a SARIF 2.1.0 log with a single run. Rules are identified by their codes and
+// listed in order, results keep the order of the records. File paths are made relative to root.
+func NewSarifLog(records []Record, root, version string) SarifLog {
+	infos := make([]RuleInfo, 0)
+	seen := make(map[Rule]struct{})
+	for _, record := range records {
+		if _, ok := seen[record.Rule]; ok {
+			continue
+		}
+
+		seen[record.Rule] = struct{}{}
+		infos = append(infos, record.Rule.Info())
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return sarifRuleID(infos[i]) < sarifRuleID(infos[j])
+	})
+
+	rules := make([]SarifRule, 0, len(infos))
+	ruleIndexes := make(map[Rule]int, len(infos))
+	for i, info := range infos {
+		rules = append(rules, SarifRule{
+			ID:               sarifRuleID(info),
+			Name:             string(info.Rule),
+			ShortDescription: SarifMessage{Text: info.Description},
+		})
+		ruleIndexes[info.Rule] = i
+	}
+
+	results := make([]SarifResult, 0, len(records))
+	for _, record := range records {
+		results = append(results, SarifResult{
+			RuleID:    sarifRuleID(record.Rule.Info()),
+			RuleIndex: ruleIndexes[record.Rule],
+			Level:     sarifLevel(record.Severity),
+			Message:   SarifMessage{Text: record.Message},
+			Locations: sarifLocations(record, root),
+		})
+	}
+
+	return SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{
+			{
+				Tool: SarifTool{
+					Driver: SarifDriver{
+						Name:           toolName,
+						Version:        version,
+						InformationURI: toolURI,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func sarifRuleID(info RuleInfo) string {
+	if info.Code == "" {
+		return string(info.Rule)
+	}
+
+	return info.Code
+}
+
+func sarifLevel(severity Severity) string {
+	if severity == SeverityWarning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+func sarifLocations(record Record, root string) []SarifLocation {
+	if record.FilePath == "" {
+		return nil
+	}
+
+	filePath, err := filepath.Rel(root, record.FilePath)
+	if err != nil {
+		filePath = record.FilePath
+	}
+
+	location := SarifLocation{
+		PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{
+				URI:       filepath.ToSlash(filePath),
+				URIBaseID: sarifSourceRoot,
+			},
+		},
+	}
+
+	if record.Line > 0 {
+		location.PhysicalLocation.Region = &SarifRegion{StartLine: record.Line, StartColumn: record.Column}
+	}
+
+	return []SarifLocation{location}
+}
@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSarifLog(t *testing.T) {
+	records := []Record{
+		{
+			FilePath: "./content/go/basics/10-variables.md",
+			Rule:     RuleTagCase,
+			Message:  "tag is not lowercase: Basics",
+			Severity: SeverityError,
+		},
+		{
+			FilePath: "content/go/basics/20-loops.md",
+			Rule:     RuleInternalLinkNotFound,
+			Message:  "internal link not found: /go/basics/loop/",
+			Severity: SeverityWarning,
+			Line:     12,
+			Column:   5,
+		},
+	}
+
+	// execute
+	got := NewSarifLog(records, ".", "1.2.3")
+
+	// verify
+	assert.Equal(t, "2.1.0", got.Version)
+	require.Len(t, got.Runs, 1)
+
+	run := got.Runs[0]
+	assert.Equal(t, "content-checker", run.Tool.Driver.Name)
+	assert.Equal(t, "1.2.3", run.Tool.Driver.Version)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "CC109", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, string(RuleTagCase), run.Tool.Driver.Rules[0].Name)
+	assert.Equal(t, "tag is not lowercase", run.Tool.Driver.Rules[0].ShortDescription.Text)
+	assert.Equal(t, "CC501", run.Tool.Driver.Rules[1].ID)
+
+	require.Len(t, run.Results, 2)
+
+	assert.Equal(t, "CC109", run.Results[0].RuleID)
+	assert.Equal(t, 0, run.Results[0].RuleIndex)
+	assert.Equal(t, "error", run.Results[0].Level)
+	require.Len(t, run.Results[0].Locations, 1)
+	assert.Equal(t, SarifArtifactLocation{URI: "content/go/basics/10-variables.md", URIBaseID: "%SRCROOT%"}, run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Nil(t, run.Results[0].Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, 1, run.Results[1].RuleIndex)
+	assert.Equal(t, "warning", run.Results[1].Level)
+	assert.Equal(t, &SarifRegion{StartLine: 12, StartColumn: 5}, run.Results[1].Locations[0].PhysicalLocation.Region)
+}
+
+func Test_sarifLocations(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     string
+		filePath string
+		want     string
+	}{
+		{name: "current directory", root: ".", filePath: "content/go/basics/10-variables.md", want: "content/go/basics/10-variables.md"},
+		{name: "subdirectory", root: "site", filePath: "site/content/go/basics/10-variables.md", want: "content/go/basics/10-variables.md"},
+		{name: "absolute", root: "/srv/site", filePath: "/srv/site/content/go/basics/10-variables.md", want: "content/go/basics/10-variables.md"},
+		{name: "outside of root", root: "/srv/site", filePath: "content/go/basics/10-variables.md", want: "content/go/basics/10-variables.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			got := sarifLocations(Record{FilePath: tt.filePath, Line: 3}, tt.root)
+
+			// verify
+			require.Len(t, got, 1)
+			assert.Equal(t, SarifArtifactLocation{URI: tt.want, URIBaseID: "%SRCROOT%"}, got[0].PhysicalLocation.ArtifactLocation)
+		})
+	}
+}
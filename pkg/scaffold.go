@@ -0,0 +1,117 @@
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (c *Chapter) NextPageWeight() int {
+	largestWeight := 0
+
+	for _, page := range c.Pages {
+		if page.Title == "_index.md" {
+			continue
+		}
+
+		largestWeight = max(largestWeight, page.GetWeight())
+	}
+
+	return (largestWeight/pageWeightStep + 1) * pageWeightStep
+}
+
+func (c Course) NextChapterWeight() int {
+	largestWeight := 0
+
+	for _, chapter := range c.Chapters {
+		largestWeight = max(largestWeight, chapter.GetWeight())
+	}
+
+	return largestWeight + 1
+}
+
+func NewPageSkeleton(title string, weight int, practice bool) (string, string) {
+	slug := slugify(title)
+
+	var sb strings.Builder
+
+	sb.WriteString("+++" + EOL)
+	sb.WriteString("title = " + strconv.Quote(title) + EOL)
+	sb.WriteString("weight = " + strconv.Itoa(weight) + EOL)
+	sb.WriteString("state = " + strconv.Quote(string(Stub)) + EOL)
+	sb.WriteString("slug = " + strconv.Quote(slug) + EOL)
+	sb.WriteString("tags = []" + EOL)
+	sb.WriteString("audience = " + strconv.Quote(string(All)) + EOL)
+	sb.WriteString("+++" + EOL)
+
+	if practice {
+		// the description must not be empty, otherwise the page is not recognized as a practice page
+		writeSection(&sb, sectionDescription, "<!-- describe the challenges here -->")
+		writeSection(&sb, sectionRecommendedChallenges, "")
+		writeSection(&sb, sectionAdditionalChallenges, "")
+	} else {
+		for _, title := range getSectionOrder() {
+			switch title {
+			case sectionRoot:
+				continue
+			case sectionMainVideo:
+				// main-missing would be reported as an error until a video is found
+				writeSection(&sb, title, "{{< main-really-missing >}}")
+			case sectionSummary:
+				writeSection(&sb, title, "<!-- summarize the lesson here -->")
+			case sectionTopics:
+				writeSection(&sb, title, "<!-- list the topics of the lesson here -->")
+			default:
+				writeSection(&sb, title, "")
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d-%s.md", weight, slug), sb.String()
+}
+
+func NewChapterSkeleton(title string, weight int) (string, string) {
+	var sb strings.Builder
+
+	sb.WriteString("+++" + EOL)
+	sb.WriteString(`archetype = "chapter"` + EOL)
+	sb.WriteString("title = " + strconv.Quote(title) + EOL)
+	sb.WriteString("weight = " + strconv.Itoa(weight) + EOL)
+	sb.WriteString("audience = " + strconv.Quote(string(All)) + EOL)
+	sb.WriteString("+++" + EOL)
+
+	writeSection(&sb, sectionEpisodes, "")
+
+	return slugify(title), sb.String()
+}
+
+func getSectionOrder() []string {
+	titles := make([]string, len(defaultBodySectionMap))
+	for title, i := range defaultBodySectionMap {
+		titles[i] = title
+	}
+
+	return titles
+}
+
+func writeSection(sb *strings.Builder, title, content string) {
+	heading := capitalizeWords(title)
+
+	sb.WriteString(EOL)
+	sb.WriteString(heading + EOL)
+	sb.WriteString(strings.Repeat("-", len(heading)) + EOL)
+
+	if content != "" {
+		sb.WriteString(EOL)
+		sb.WriteString(content + EOL)
+	}
+}
+
+func capitalizeWords(title string) string {
+	words := strings.Fields(title)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
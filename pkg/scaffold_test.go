@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChapter_NextPageWeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages Pages
+		want  int
+	}{
+		{
+			name:  "empty",
+			pages: Pages{{Title: "_index.md", Content: Content{Weight: "3"}}},
+			want:  10,
+		},
+		{
+			name: "after largest",
+			pages: Pages{
+				{Title: "_index.md", Content: Content{Weight: "3"}},
+				{Title: "20-foo.md", Content: Content{Weight: "20"}},
+				{Title: "10-bar.md", Content: Content{Weight: "10"}},
+			},
+			want: 30,
+		},
+		{
+			name: "weird weight",
+			pages: Pages{
+				{Title: "25-foo.md", Content: Content{Weight: "25"}},
+			},
+			want: 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chapter := &Chapter{Pages: tt.pages}
+
+			// execute
+			got := chapter.NextPageWeight()
+
+			// verify
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCourse_NextChapterWeight(t *testing.T) {
+	course := Course{
+		Chapters: Chapters{
+			{Pages: Pages{{Title: "_index.md", Content: Content{Weight: "2"}}}},
+			{Pages: Pages{{Title: "_index.md", Content: Content{Weight: "1"}}}},
+		},
+	}
+
+	// execute
+	got := course.NextChapterWeight()
+
+	// verify
+	assert.Equal(t, 3, got)
+}
+
+func TestNewPageSkeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		practice bool
+		wantBody Body
+	}{
+		{
+			name:     "lesson",
+			practice: false,
+			wantBody: DefaultBody{},
+		},
+		{
+			name:     "practice",
+			practice: true,
+			wantBody: &PracticeBody{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			fileName, rawContent := NewPageSkeleton("Maps & Slices", 30, tt.practice)
+
+			// verify
+			assert.Equal(t, "30-maps-and-slices.md", fileName)
+
+			content, err := ParseMarkdown(rawContent)
+			require.NoError(t, err)
+
+			assert.IsType(t, tt.wantBody, content.Body)
+			assert.Equal(t, "Maps & Slices", content.Title)
+			assert.Equal(t, "maps-and-slices", content.Slug)
+			assert.Equal(t, "30", content.Weight)
+			assert.Equal(t, Stub, content.State)
+
+			assert.Empty(t, content.GetIssues("content/go/basics/"+fileName, "go", "basics", fileName))
+		})
+	}
+}
+
+func TestNewChapterSkeleton(t *testing.T) {
+	// execute
+	dirName, rawContent := NewChapterSkeleton("Web I.", 4)
+
+	// verify
+	assert.Equal(t, "web-i", dirName)
+	assert.Equal(t, `+++
+archetype = "chapter"
+title = "Web I."
+weight = 4
+audience = "all"
++++
+
+Episodes
+--------
+`, rawContent)
+
+	content, err := ParseMarkdown(rawContent)
+	require.NoError(t, err)
+
+	assert.Empty(t, content.GetIssues("content/go/web-i/_index.md", "go", "web-i", "_index.md"))
+}
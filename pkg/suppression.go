@@ -0,0 +1,134 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+)
+
+const suppressionKey = "checkerIgnore"
+
+// Suppressions declared in HTML comments only apply to the section containing the comment.
+type Suppression struct {
+	Rule     string
+	Position Position
+	// FromLine and ToLine limit the lines the suppression applies to, zero values mean the whole file
+	FromLine int
+	ToLine   int
+}
+
+func (s Suppression) IsFileWide() bool {
+	return s.FromLine == 0 && s.ToLine == 0
+}
+
+func (s Suppression) Matches(issue Issue) bool {
+	info, ok := LookupRule(s.Rule)
+	if !ok || info.Rule != issue.Rule {
+		return false
+	}
+
+	if s.IsFileWide() {
+		return true
+	}
+
+	return issue.Position.Line >= s.FromLine && issue.Position.Line <= s.ToLine
+}
+
+// unsuppressibleRules are checked before the page could be parsed or across pages, e.g. by check-links, orphans, assets
+// or the order checks, so the suppressions of a page never apply to them.
+var unsuppressibleRules = map[Rule]struct{}{
+	RuleFileUnreadable:         {},
+	RuleFileEmpty:              {},
+	RuleFrontMatterInvalid:     {},
+	RulePageWeight:             {},
+	RulePageMissing:            {},
+	RulePageWeightDuplicate:    {},
+	RulePageWeightGap:          {},
+	RuleChapterMissing:         {},
+	RuleChapterWeightDuplicate: {},
+	RuleChapterWeightGap:       {},
+	RuleInternalLinkNotFound:   {},
+	RuleExternalLinkStatus:     {},
+	RuleFileLinkNotFound:       {},
+	RuleInternalLinkAnchor:     {},
+	RuleExternalLinkMoved:      {},
+	RuleExternalRedirectLoop:   {},
+	RuleExternalRedirectDomain: {},
+	RuleExternalLinkError:      {},
+	RuleOrphanPage:             {},
+	RuleAssetUnreferenced:      {},
+	RuleFileLinkCase:           {},
+	RuleRefNotFound:            {},
+	RuleRefAmbiguous:           {},
+	RuleInternalLinkSlash:      {},
+	RuleAliasCollision:         {},
+}
+
+var regexSuppression = regexp.MustCompile(`<!--\s*content-checker:ignore\s+(.*?)\s*-->`)
+
+func getSuppressions(content string, ignored []string, positions map[string]Position, sections Sections, lineCount int) []Suppression {
+	var suppressions []Suppression
+
+	for _, rule := range ignored {
+		suppressions = append(suppressions, Suppression{Rule: rule, Position: positions[suppressionKey]})
+	}
+
+	for _, match := range regexSuppression.FindAllStringSubmatchIndex(content, -1) {
+		position := positionAt(content, match[0])
+		fromLine, toLine := sections.LinesAround(position.Line, lineCount)
+
+		for _, rule := range strings.FieldsFunc(content[match[2]:match[3]], isSuppressionSeparator) {
+			suppressions = append(suppressions, Suppression{Rule: rule, Position: position, FromLine: fromLine, ToLine: toLine})
+		}
+	}
+
+	return suppressions
+}
+
+func isSuppressionSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
+
+func applySuppressions(issues []Issue, suppressions []Suppression) []Issue {
+	if len(suppressions) == 0 {
+		return issues
+	}
+
+	used := make([]bool, len(suppressions))
+
+	var result []Issue
+	for _, issue := range issues {
+		suppressed := false
+
+		for i, suppression := range suppressions {
+			if suppression.Matches(issue) {
+				used[i] = true
+				suppressed = true
+			}
+		}
+
+		if !suppressed {
+			result = append(result, issue)
+		}
+	}
+
+	for i, suppression := range suppressions {
+		info, ok := LookupRule(suppression.Rule)
+		if !ok {
+			result = append(result, NewIssue(RuleSuppressionUnknown, "unknown rule in suppression: "+suppression.Rule).At(suppression.Position))
+
+			continue
+		}
+
+		if _, ok := unsuppressibleRules[info.Rule]; ok {
+			result = append(result, NewIssue(RuleSuppressionUnsupported, "rule cannot be suppressed in a page: "+suppression.Rule).At(suppression.Position))
+
+			continue
+		}
+
+		if !used[i] {
+			result = append(result, NewIssue(RuleSuppressionUnused, "unused suppression: "+suppression.Rule).At(suppression.Position))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContent_GetIssues_Suppressions(t *testing.T) {
+	const header = `+++
+title = "Loops!"
+weight = 20
+state = "incomplete"
+slug = "loops"
+tags = ["go"]
+audience = "all"
+audienceImportance = "essential"
+%s+++
+`
+	const body = `
+Summary
+-------
+
+- foo
+
+Related Videos
+--------------
+
+%s
+### Long one
+
+{{< time 150 >}} {{< badge-extra >}}
+
+{{< youtube abc >}}
+
+Exercises
+---------
+
+%s
+- bar
+`
+
+	tests := []struct {
+		name      string
+		header    string
+		related   string
+		exercises string
+		want      map[Rule]Position
+	}{
+		{
+			name: "no suppressions",
+			want: map[Rule]Position{
+				RuleBadgeLength:   {Line: 22, Column: 1},
+				RuleStateMismatch: {Line: 4, Column: 1},
+				RuleTopicsMissing: {},
+			},
+		},
+		{
+			name:    "comment in the same section",
+			related: "<!-- content-checker:ignore badge-length -->",
+			want: map[Rule]Position{
+				RuleStateMismatch: {Line: 4, Column: 1},
+				RuleTopicsMissing: {},
+			},
+		},
+		{
+			name:      "comment in another section",
+			exercises: "<!-- content-checker:ignore CC309 -->",
+			want: map[Rule]Position{
+				RuleBadgeLength:       {Line: 22, Column: 1},
+				RuleStateMismatch:     {Line: 4, Column: 1},
+				RuleTopicsMissing:     {},
+				RuleSuppressionUnused: {Line: 29, Column: 1},
+			},
+		},
+		{
+			name:   "front matter",
+			header: "checkerIgnore = [\"topics-missing\", \"badge-length\"]\n",
+			want: map[Rule]Position{
+				RuleStateMismatch: {Line: 4, Column: 1},
+			},
+		},
+		{
+			name:   "unsupported rule",
+			header: "checkerIgnore = [\"topics-missing\", \"orphan-page\"]\n",
+			want: map[Rule]Position{
+				RuleBadgeLength:            {Line: 23, Column: 1},
+				RuleStateMismatch:          {Line: 4, Column: 1},
+				RuleSuppressionUnsupported: {Line: 9, Column: 1},
+			},
+		},
+		{
+			name:   "unknown rule",
+			header: "checkerIgnore = [\"topics-missing\", \"badge-lenght\"]\n",
+			want: map[Rule]Position{
+				RuleBadgeLength:        {Line: 23, Column: 1},
+				RuleStateMismatch:      {Line: 4, Column: 1},
+				RuleSuppressionUnknown: {Line: 9, Column: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawContent := fmt.Sprintf(header, tt.header) + fmt.Sprintf(body, tt.related, tt.exercises)
+
+			content, err := ParseMarkdown(rawContent)
+			require.NoError(t, err)
+
+			// execute
+			issues := content.GetIssues("content/go/basics/20-loops.md", "go", "basics", "20-loops.md")
+
+			// verify
+			got := make(map[Rule]Position, len(issues))
+			for _, issue := range issues {
+				got[issue.Rule] = issue.Position
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSections_LinesAround(t *testing.T) {
+	sections := Sections{
+		{Title: "", Position: Position{Line: 5, Column: 1}},
+		{Title: "Summary", Position: Position{Line: 7, Column: 1}},
+		{Title: "Exercises", Position: Position{Line: 12, Column: 1}},
+	}
+
+	tests := []struct {
+		name     string
+		line     int
+		wantFrom int
+		wantTo   int
+	}{
+		{name: "root", line: 5, wantFrom: 5, wantTo: 6},
+		{name: "middle", line: 9, wantFrom: 7, wantTo: 11},
+		{name: "last", line: 14, wantFrom: 12, wantTo: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// execute
+			gotFrom, gotTo := sections.LinesAround(tt.line, 20)
+
+			// verify
+			assert.Equal(t, tt.wantFrom, gotFrom)
+			assert.Equal(t, tt.wantTo, gotTo)
+		})
+	}
+}